@@ -0,0 +1,20 @@
+package users
+
+import "context"
+
+// actorKey is the context key for the acting user's ID. It is unexported
+// so no other package can collide with it
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the ID of the user performing
+// the current operation
+func WithActor(ctx context.Context, actorID int64) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// ActorFromContext returns the acting user's ID stored in ctx by WithActor,
+// and false if there isn't one
+func ActorFromContext(ctx context.Context) (int64, bool) {
+	actorID, ok := ctx.Value(actorKey{}).(int64)
+	return actorID, ok
+}
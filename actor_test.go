@@ -0,0 +1,50 @@
+package users
+
+import (
+	"context"
+	"testing"
+)
+
+// TestActorFromContext is a test function for WithActor and ActorFromContext
+func TestActorFromContext(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		ctx           context.Context
+		expectedActor int64
+		expectedFound bool
+	}{
+		{
+			"Actor Set",
+			WithActor(context.Background(), 1),
+			1,
+			true,
+		},
+		{
+			"Actor Overridden",
+			WithActor(WithActor(context.Background(), 1), 1234567890),
+			1234567890,
+			true,
+		},
+		{
+			"Actor Missing",
+			context.Background(),
+			0,
+			false,
+		},
+		{
+			"Plain Key Does Not Collide",
+			context.WithValue(context.Background(), "actor", int64(1)),
+			0,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		actorID, found := ActorFromContext(c.ctx)
+		if actorID != c.expectedActor || found != c.expectedFound {
+			t.Errorf("Error, expected [%d, %t] but got [%d, %t] in test [%s]",
+				c.expectedActor, c.expectedFound, actorID, found, c.name)
+		}
+	}
+}
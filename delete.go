@@ -0,0 +1,115 @@
+package users
+
+import (
+	"database/sql"
+	"strings"
+)
+
+const selectUserForUpdateQuery = "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=? for update"
+const deleteUserQuery = "delete from Users where id=?"
+
+// selectUserForUpdate reads the user with the given ID inside tx, locking the
+// row until the transaction ends. Returns ErrUserNotFound if there is no such user
+func selectUserForUpdate(tx *sql.Tx, id int64) (*User, error) {
+	user := &User{}
+	err := tx.QueryRow(selectUserForUpdateQuery, id).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PassHash,
+		&user.UserName,
+		&user.FirstName,
+		&user.LastName,
+		&user.PhotoURL,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteReturning deletes the user with the given ID and returns the user as
+// it was just before deletion, so callers can clean up anything it referenced
+// such as the avatar behind PhotoURL. The read and delete happen in one
+// transaction. Returns ErrUserNotFound if there is no user with the ID
+func (s *SQLStore) DeleteReturning(id int64) (*User, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := selectUserForUpdate(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := tx.Exec(deleteUserQuery, id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteByIDsReturning deletes the users with the given IDs and returns the
+// IDs that actually existed and were deleted, in ascending order. IDs with no
+// matching user are skipped. An empty slice deletes nothing without querying
+func (s *SQLStore) DeleteByIDsReturning(ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	rows, err := tx.Query("select id from Users where id in (?"+strings.Repeat(",?", len(ids)-1)+") order by id for update", args...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	deleted := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		deleted = append(deleted, id)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(deleted) == 0 {
+		tx.Rollback()
+		return deleted, nil
+	}
+
+	deleteArgs := make([]interface{}, 0, len(deleted))
+	for _, id := range deleted {
+		deleteArgs = append(deleteArgs, id)
+	}
+	if _, err := tx.Exec("delete from Users where id in (?"+strings.Repeat(",?", len(deleted)-1)+")", deleteArgs...); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
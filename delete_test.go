@@ -0,0 +1,194 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestDeleteReturning is a test function for the SQLStore's DeleteReturning
+func TestDeleteReturning(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name         string
+		expectedUser *User
+		idToDelete   int64
+		expectError  bool
+	}{
+		{
+			"User Deleted",
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"https://cdn.example.com/avatars/1.png",
+			},
+			1,
+			false,
+		},
+		{
+			"User Not Found",
+			nil,
+			2,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		row := mock.NewRows([]string{
+			"ID",
+			"Email",
+			"PassHash",
+			"UserName",
+			"FirstName",
+			"LastName",
+			"PhotoURL"},
+		)
+
+		mock.ExpectBegin()
+		if c.expectError {
+			// Set up an expected query that finds nothing and nothing is deleted
+			mock.ExpectQuery(regexp.QuoteMeta(selectUserForUpdateQuery)).WithArgs(c.idToDelete).WillReturnRows(row)
+			mock.ExpectRollback()
+
+			// Test DeleteReturning()
+			user, err := mainSQLStore.DeleteReturning(c.idToDelete)
+			if user != nil || err != ErrUserNotFound {
+				t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+			}
+		} else {
+			row.AddRow(
+				c.expectedUser.ID,
+				c.expectedUser.Email,
+				c.expectedUser.PassHash,
+				c.expectedUser.UserName,
+				c.expectedUser.FirstName,
+				c.expectedUser.LastName,
+				c.expectedUser.PhotoURL,
+			)
+			mock.ExpectQuery(regexp.QuoteMeta(selectUserForUpdateQuery)).WithArgs(c.idToDelete).WillReturnRows(row)
+			mock.ExpectExec(regexp.QuoteMeta(deleteUserQuery)).WithArgs(c.idToDelete).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			// Test DeleteReturning()
+			user, err := mainSQLStore.DeleteReturning(c.idToDelete)
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(user, c.expectedUser) {
+				t.Errorf("Error, invalid match in test [%s]", c.name)
+			}
+			if user != nil && user.PhotoURL != c.expectedUser.PhotoURL {
+				t.Errorf("Error, expected PhotoURL [%s] but got [%s] in test [%s]", c.expectedUser.PhotoURL, user.PhotoURL, c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+// TestDeleteByIDsReturning is a test function for the SQLStore's DeleteByIDsReturning
+func TestDeleteByIDsReturning(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name        string
+		idsToDelete []int64
+		existingIDs []int64
+		selectQuery string
+		deleteQuery string
+	}{
+		{
+			"Some IDs Absent",
+			[]int64{1, 2, 3, 4},
+			[]int64{1, 3},
+			"select id from Users where id in (?,?,?,?) order by id for update",
+			"delete from Users where id in (?,?)",
+		},
+		{
+			"All IDs Present",
+			[]int64{5, 6},
+			[]int64{5, 6},
+			"select id from Users where id in (?,?) order by id for update",
+			"delete from Users where id in (?,?)",
+		},
+		{
+			"No IDs Present",
+			[]int64{7},
+			[]int64{},
+			"select id from Users where id in (?) order by id for update",
+			"",
+		},
+		{
+			"Empty Slice",
+			[]int64{},
+			[]int64{},
+			"",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		if len(c.selectQuery) > 0 {
+			mock.ExpectBegin()
+			selectArgs := make([]driver.Value, 0, len(c.idsToDelete))
+			for _, id := range c.idsToDelete {
+				selectArgs = append(selectArgs, id)
+			}
+			rows := mock.NewRows([]string{"ID"})
+			deleteArgs := make([]driver.Value, 0, len(c.existingIDs))
+			for _, id := range c.existingIDs {
+				rows.AddRow(id)
+				deleteArgs = append(deleteArgs, id)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(c.selectQuery)).WithArgs(selectArgs...).WillReturnRows(rows)
+
+			// Only the IDs that exist are deleted
+			if len(c.deleteQuery) > 0 {
+				mock.ExpectExec(regexp.QuoteMeta(c.deleteQuery)).WithArgs(deleteArgs...).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(c.existingIDs))))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+		}
+
+		// Test DeleteByIDsReturning()
+		deleted, err := mainSQLStore.DeleteByIDsReturning(c.idsToDelete)
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(deleted, c.existingIDs) {
+			t.Errorf("Error, expected [%v] but got [%v] in test [%s]", c.existingIDs, deleted, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package users
+
+// countByDomainQuery groups users by the part of their email after the @.
+// substring_index is MySQL-specific; on PostgreSQL use split_part(email,'@',2)
+const countByDomainQuery = "select substring_index(email,'@',-1) as domain, count(*) from Users group by domain"
+
+// CountByDomain returns the number of users for each email domain
+func (s *SQLStore) CountByDomain() (map[string]int64, error) {
+	rows, err := s.db.Query(countByDomainQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var domain string
+		var count int64
+		if err := rows.Scan(&domain, &count); err != nil {
+			return nil, err
+		}
+		counts[domain] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
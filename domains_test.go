@@ -0,0 +1,83 @@
+package users
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestCountByDomain is a test function for the SQLStore's CountByDomain
+func TestCountByDomain(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name           string
+		expectedCounts map[string]int64
+		expectError    bool
+	}{
+		{
+			"Several Domains",
+			map[string]int64{
+				"test.com":    3,
+				"example.com": 1,
+				"uw.edu":      12,
+			},
+			false,
+		},
+		{
+			"No Users",
+			map[string]int64{},
+			false,
+		},
+		{
+			"Query Error",
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		query := regexp.QuoteMeta(countByDomainQuery)
+
+		if c.expectError {
+			queryErr := errors.New("query failed")
+			mock.ExpectQuery(query).WillReturnError(queryErr)
+
+			// Test CountByDomain()
+			counts, err := mainSQLStore.CountByDomain()
+			if counts != nil || err == nil {
+				t.Errorf("Expected error [%v] but got [%v] instead", queryErr, err)
+			}
+		} else {
+			rows := mock.NewRows([]string{"Domain", "Count"})
+			for domain, count := range c.expectedCounts {
+				rows.AddRow(domain, count)
+			}
+			mock.ExpectQuery(query).WillReturnRows(rows)
+
+			// Test CountByDomain()
+			counts, err := mainSQLStore.CountByDomain()
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(counts, c.expectedCounts) {
+				t.Errorf("Error, expected [%v] but got [%v] in test [%s]", c.expectedCounts, counts, c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package users
+
+import "strings"
+
+const selectDuplicateEmailsQuery = "select email from Users group by email having count(*) > 1"
+
+// FindDuplicateEmails returns every email that appears on more than one
+// user, mapped to the IDs of those users in ascending order. Emails that
+// differ only in case are duplicates when the column's collation says so,
+// and are reported under the single email the grouping query returned
+func (s *SQLStore) FindDuplicateEmails() (map[string][]int64, error) {
+	rows, err := s.db.Query(selectDuplicateEmailsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	duplicates := make(map[string][]int64)
+	if len(emails) == 0 {
+		return duplicates, nil
+	}
+
+	args := make([]interface{}, 0, len(emails))
+	for _, email := range emails {
+		args = append(args, email)
+	}
+	query := "select email,id from Users where email in (?" +
+		strings.Repeat(",?", len(emails)-1) + ") order by id"
+	idRows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer idRows.Close()
+
+	for idRows.Next() {
+		var email string
+		var id int64
+		if err := idRows.Scan(&email, &id); err != nil {
+			return nil, err
+		}
+		if group, found := matchGroup(email, emails); found {
+			duplicates[group] = append(duplicates[group], id)
+		}
+	}
+	if err := idRows.Err(); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
+}
+
+// matchGroup returns the group email a stored email belongs to. An exact match
+// wins, so case-variant emails that a case-sensitive collation grouped
+// separately stay separate. Otherwise a case-insensitive match is used, since
+// under a case-insensitive collation the group's representative may differ in
+// case from what is stored
+func matchGroup(email string, groups []string) (string, bool) {
+	for _, group := range groups {
+		if email == group {
+			return group, true
+		}
+	}
+	for _, group := range groups {
+		if strings.EqualFold(email, group) {
+			return group, true
+		}
+	}
+	return "", false
+}
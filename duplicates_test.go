@@ -0,0 +1,122 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestFindDuplicateEmails is a test function for the SQLStore's FindDuplicateEmails
+func TestFindDuplicateEmails(t *testing.T) {
+	type storedRow struct {
+		email string
+		id    int64
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name               string
+		duplicateEmails    []string
+		idQuery            string
+		storedRows         []storedRow
+		expectedDuplicates map[string][]int64
+	}{
+		{
+			"Duplicate Groups Found",
+			[]string{"a@test.com", "b@test.com"},
+			"select email,id from Users where email in (?,?) order by id",
+			[]storedRow{
+				{"a@test.com", 1},
+				{"b@test.com", 2},
+				{"b@test.com", 3},
+				{"a@test.com", 4},
+				{"b@test.com", 7},
+			},
+			map[string][]int64{
+				"a@test.com": {1, 4},
+				"b@test.com": {2, 3, 7},
+			},
+		},
+		{
+			"Case-Variant Duplicates",
+			[]string{"A@test.com"},
+			"select email,id from Users where email in (?) order by id",
+			[]storedRow{
+				{"A@test.com", 1},
+				{"a@test.com", 2},
+				{"a@TEST.com", 5},
+			},
+			map[string][]int64{
+				"A@test.com": {1, 2, 5},
+			},
+		},
+		{
+			"Case-Variant Groups Under Binary Collation",
+			[]string{"A@test.com", "a@test.com"},
+			"select email,id from Users where email in (?,?) order by id",
+			[]storedRow{
+				{"A@test.com", 1},
+				{"a@test.com", 2},
+				{"A@test.com", 3},
+				{"a@test.com", 4},
+			},
+			map[string][]int64{
+				"A@test.com": {1, 3},
+				"a@test.com": {2, 4},
+			},
+		},
+		{
+			"No Duplicates",
+			[]string{},
+			"",
+			nil,
+			map[string][]int64{},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		emailRows := mock.NewRows([]string{"Email"})
+		for _, email := range c.duplicateEmails {
+			emailRows.AddRow(email)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(selectDuplicateEmailsQuery)).WillReturnRows(emailRows)
+
+		// The follow-up query only runs when there are duplicates
+		if len(c.duplicateEmails) > 0 {
+			args := make([]driver.Value, 0, len(c.duplicateEmails))
+			for _, email := range c.duplicateEmails {
+				args = append(args, email)
+			}
+			idRows := mock.NewRows([]string{"Email", "ID"})
+			for _, row := range c.storedRows {
+				idRows.AddRow(row.email, row.id)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(c.idQuery)).WithArgs(args...).WillReturnRows(idRows)
+		}
+
+		// Test FindDuplicateEmails()
+		duplicates, err := mainSQLStore.FindDuplicateEmails()
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(duplicates, c.expectedDuplicates) {
+			t.Errorf("Error, expected [%v] but got [%v] in test [%s]", c.expectedDuplicates, duplicates, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package users
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const selectExportQuery = "select id,email,username,firstName,lastName,photoUrl from Users order by id"
+const selectExportWithPassHashQuery = "select id,email,passHash,username,firstName,lastName,photoUrl from Users order by id"
+
+// exportedUser is the JSON form of a User written by ExportAll
+type exportedUser struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	PassHash  []byte `json:"passHash,omitempty"`
+	UserName  string `json:"userName"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	PhotoURL  string `json:"photoURL"`
+}
+
+// ExportAll writes every user to w as newline-delimited JSON, one object per
+// line in ID order. Users are streamed from the DB and written one at a time,
+// so memory use stays flat however big the table is. PassHash is left out;
+// use ExportAllWithPassHash to include it
+func (s *SQLStore) ExportAll(w io.Writer) error {
+	return s.exportAll(w, false)
+}
+
+// ExportAllWithPassHash is ExportAll with each user's PassHash included,
+// base64-encoded. Only use it for backups that are stored securely
+func (s *SQLStore) ExportAllWithPassHash(w io.Writer) error {
+	return s.exportAll(w, true)
+}
+
+// exportAll streams every user to w as NDJSON, including the password hash
+// only if includePassHash is set
+func (s *SQLStore) exportAll(w io.Writer, includePassHash bool) error {
+	query := selectExportQuery
+	if includePassHash {
+		query = selectExportWithPassHashQuery
+	}
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	for rows.Next() {
+		u := exportedUser{}
+		dest := []interface{}{&u.ID, &u.Email, &u.UserName, &u.FirstName, &u.LastName, &u.PhotoURL}
+		if includePassHash {
+			dest = []interface{}{&u.ID, &u.Email, &u.PassHash, &u.UserName, &u.FirstName, &u.LastName, &u.PhotoURL}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := enc.Encode(u); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
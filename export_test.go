@@ -0,0 +1,97 @@
+package users
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestExportAll is a test function for the SQLStore's ExportAll and ExportAllWithPassHash
+func TestExportAll(t *testing.T) {
+	users := []*User{
+		{1, "one@test.com", []byte("passhash1"), "one", "firstname", "lastname", "photourl"},
+		{2, "two@test.com", []byte("passhash2"), "two", "firstname", "lastname", ""},
+		{3, "three@test.com", []byte("passhash3"), "three", "firstname", "lastname", "photourl"},
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name            string
+		includePassHash bool
+		query           string
+		expectedLines   []string
+	}{
+		{
+			"PassHash Excluded By Default",
+			false,
+			selectExportQuery,
+			[]string{
+				`{"id":1,"email":"one@test.com","userName":"one","firstName":"firstname","lastName":"lastname","photoURL":"photourl"}`,
+				`{"id":2,"email":"two@test.com","userName":"two","firstName":"firstname","lastName":"lastname","photoURL":""}`,
+				`{"id":3,"email":"three@test.com","userName":"three","firstName":"firstname","lastName":"lastname","photoURL":"photourl"}`,
+			},
+		},
+		{
+			"PassHash Included Explicitly",
+			true,
+			selectExportWithPassHashQuery,
+			[]string{
+				`{"id":1,"email":"one@test.com","passHash":"cGFzc2hhc2gx","userName":"one","firstName":"firstname","lastName":"lastname","photoURL":"photourl"}`,
+				`{"id":2,"email":"two@test.com","passHash":"cGFzc2hhc2gy","userName":"two","firstName":"firstname","lastName":"lastname","photoURL":""}`,
+				`{"id":3,"email":"three@test.com","passHash":"cGFzc2hhc2gz","userName":"three","firstName":"firstname","lastName":"lastname","photoURL":"photourl"}`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		var rows *sqlmock.Rows
+		if c.includePassHash {
+			rows = mock.NewRows([]string{"ID", "Email", "PassHash", "UserName", "FirstName", "LastName", "PhotoURL"})
+			for _, u := range users {
+				rows.AddRow(u.ID, u.Email, u.PassHash, u.UserName, u.FirstName, u.LastName, u.PhotoURL)
+			}
+		} else {
+			// The hash isn't even selected when it isn't being exported
+			rows = mock.NewRows([]string{"ID", "Email", "UserName", "FirstName", "LastName", "PhotoURL"})
+			for _, u := range users {
+				rows.AddRow(u.ID, u.Email, u.UserName, u.FirstName, u.LastName, u.PhotoURL)
+			}
+		}
+		mock.ExpectQuery("^" + regexp.QuoteMeta(c.query) + "$").WillReturnRows(rows).RowsWillBeClosed()
+
+		// Test ExportAll() / ExportAllWithPassHash()
+		var buf bytes.Buffer
+		if c.includePassHash {
+			err = mainSQLStore.ExportAllWithPassHash(&buf)
+		} else {
+			err = mainSQLStore.ExportAll(&buf)
+		}
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+
+		expected := strings.Join(c.expectedLines, "\n") + "\n"
+		if buf.String() != expected {
+			t.Errorf("Error, expected output\n%s\nbut got\n%s\nin test [%s]", expected, buf.String(), c.name)
+		}
+		if !c.includePassHash && strings.Contains(buf.String(), "passHash") {
+			t.Errorf("Error, PassHash was exported by default in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
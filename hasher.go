@@ -0,0 +1,49 @@
+package users
+
+import "golang.org/x/crypto/bcrypt"
+
+// Hasher hashes passwords and checks passwords against stored hashes
+type Hasher interface {
+	// Hash returns the hash to store for the password
+	Hash(password string) ([]byte, error)
+	// Compare returns nil if the password matches the hash
+	Compare(hash []byte, password string) error
+}
+
+// RehashChecker is implemented by a Hasher that can tell when a stored hash
+// is out of date, e.g. made with older parameters or a different algorithm
+type RehashChecker interface {
+	// NeedsRehash reports whether hash should be regenerated
+	NeedsRehash(hash []byte) bool
+}
+
+// BcryptHasher is the default Hasher, using bcrypt at BcryptCost
+type BcryptHasher struct{}
+
+// Hash implements Hasher. It fails if BcryptCost is out of range rather than
+// letting bcrypt silently fall back to its default cost
+func (BcryptHasher) Hash(password string) ([]byte, error) {
+	if err := CheckBcryptCost(); err != nil {
+		return nil, err
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), BcryptCost)
+}
+
+// Compare implements Hasher
+func (BcryptHasher) Compare(hash []byte, password string) error {
+	return bcrypt.CompareHashAndPassword(hash, []byte(password))
+}
+
+// NeedsRehash implements RehashChecker. It reports true if the hash was made
+// with a cost other than BcryptCost or isn't a valid bcrypt hash
+func (BcryptHasher) NeedsRehash(hash []byte) bool {
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return true
+	}
+	return cost != BcryptCost
+}
+
+// PasswordHasher is the Hasher the store checks passwords with. Replace it to
+// switch algorithms (e.g. argon2) or to use a fast hasher in tests
+var PasswordHasher Hasher = BcryptHasher{}
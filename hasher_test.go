@@ -0,0 +1,110 @@
+package users
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeHasher is a Hasher that "hashes" by prefixing the password and
+// records what it was called with
+type fakeHasher struct {
+	compared []string
+}
+
+func (f *fakeHasher) Hash(password string) ([]byte, error) {
+	return []byte("fake:" + password), nil
+}
+
+func (f *fakeHasher) Compare(hash []byte, password string) error {
+	f.compared = append(f.compared, password)
+	if string(hash) != "fake:"+password {
+		return errors.New("mismatch")
+	}
+	return nil
+}
+
+// TestBcryptHasher is a test function for the default BcryptHasher
+func TestBcryptHasher(t *testing.T) {
+	hasher := BcryptHasher{}
+	hash, err := hasher.Hash("password123")
+	if err != nil {
+		t.Fatalf("Unexpected error hashing password: %v", err)
+	}
+	if err := hasher.Compare(hash, "password123"); err != nil {
+		t.Errorf("Unexpected error comparing the correct password: %v", err)
+	}
+	if err := hasher.Compare(hash, "wrongpassword"); err == nil {
+		t.Errorf("Expected an error comparing the wrong password but got none")
+	}
+}
+
+// TestBcryptHasherRejectsBadCost checks that BcryptHasher.Hash refuses to hash
+// with an out-of-range BcryptCost
+func TestBcryptHasherRejectsBadCost(t *testing.T) {
+	defer func(cost int) { BcryptCost = cost }(BcryptCost)
+
+	for _, cost := range []int{bcrypt.MinCost - 2, bcrypt.MaxCost + 1} {
+		BcryptCost = cost
+		hash, err := BcryptHasher{}.Hash("password123")
+		if hash != nil || err == nil {
+			t.Errorf("Expected an error hashing with cost [%d] but got none", cost)
+		}
+	}
+}
+
+// TestVerifyPasswordUsesHasher checks that VerifyPassword calls through to PasswordHasher
+func TestVerifyPasswordUsesHasher(t *testing.T) {
+	defer func(h Hasher) { PasswordHasher = h }(PasswordHasher)
+	fake := &fakeHasher{}
+	PasswordHasher = fake
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		password      string
+		expectedError error
+	}{
+		{
+			"Correct Password",
+			"password123",
+			nil,
+		},
+		{
+			"Wrong Password",
+			"wrongpassword",
+			ErrInvalidCredentials,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		storedHash, _ := fake.Hash("password123")
+		row := mock.NewRows([]string{"PassHash"}).AddRow(storedHash)
+		mock.ExpectQuery(regexp.QuoteMeta(selectPassHashQuery)).WithArgs(int64(1)).WillReturnRows(row)
+
+		// Test VerifyPassword()
+		fake.compared = nil
+		if err := mainSQLStore.VerifyPassword(1, c.password); err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+		if len(fake.compared) != 1 || fake.compared[0] != c.password {
+			t.Errorf("Error, expected the hasher to compare [%s] but it compared %v in test [%s]", c.password, fake.compared, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPStatus returns the HTTP status code a handler should respond with for
+// an error returned by the store. A nil error maps to 200 and any error the
+// store doesn't define maps to 500
+func HTTPStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUserNameExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrEmailTooLong), errors.Is(err, ErrUserNameTooLong), errors.Is(err, ErrInvalidUserName),
+		errors.Is(err, ErrInvalidToken), errors.Is(err, ErrMergeSameUser):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrTokenExpired):
+		return http.StatusGone
+	case errors.Is(err, ErrInvalidCredentials):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,37 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestHTTPStatus is a test function for HTTPStatus
+func TestHTTPStatus(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"No Error", nil, http.StatusOK},
+		{"User Not Found", ErrUserNotFound, http.StatusNotFound},
+		{"Wrapped User Not Found", fmt.Errorf("getting user: %w", ErrUserNotFound), http.StatusNotFound},
+		{"UserName Exists", ErrUserNameExists, http.StatusConflict},
+		{"Invalid UserName", ErrInvalidUserName, http.StatusBadRequest},
+		{"Email Too Long", ErrEmailTooLong, http.StatusBadRequest},
+		{"UserName Too Long", ErrUserNameTooLong, http.StatusBadRequest},
+		{"Invalid Token", ErrInvalidToken, http.StatusBadRequest},
+		{"Token Expired", ErrTokenExpired, http.StatusGone},
+		{"Merge Same User", ErrMergeSameUser, http.StatusBadRequest},
+		{"Invalid Credentials", ErrInvalidCredentials, http.StatusUnauthorized},
+		{"Unknown Error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		if status := HTTPStatus(c.err); status != c.expectedStatus {
+			t.Errorf("Error, expected status [%d] but got [%d] in test [%s]", c.expectedStatus, status, c.name)
+		}
+	}
+}
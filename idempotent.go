@@ -0,0 +1,77 @@
+package users
+
+import (
+	"database/sql"
+	"time"
+)
+
+// idempotencyKeyTTL is how long an idempotency key is remembered
+const idempotencyKeyTTL = 24 * time.Hour
+
+const selectIdempotencyKeyQuery = "select userID,expiresAt from IdempotencyKeys where idemKey=?"
+const deleteIdempotencyKeyQuery = "delete from IdempotencyKeys where idemKey=?"
+const insertIdempotencyKeyQuery = "insert into IdempotencyKeys(idemKey,userID,expiresAt) values (?,?,?)"
+
+// InsertIdempotent inserts the given user and records the key alongside it.
+// If the key was already used within idempotencyKeyTTL, the user created by
+// that earlier call is returned instead of inserting a duplicate. idemKey
+// should be the table's primary key: if a concurrent retry records the key
+// first, this call's insert is rolled back and that retry's user is returned
+func (s *SQLStore) InsertIdempotent(key string, user *User) (*User, error) {
+	if err := validateLengths(user); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var userID int64
+	var expiresAt time.Time
+	err = tx.QueryRow(selectIdempotencyKeyQuery, key).Scan(&userID, &expiresAt)
+	switch {
+	case err == nil && time.Now().Before(expiresAt):
+		// Replay of an earlier request, so hand back what it created
+		tx.Rollback()
+		return s.GetByID(userID)
+	case err == nil:
+		// The key has expired, so forget it and treat this as a new request
+		if _, err := tx.Exec(deleteIdempotencyKeyQuery, key); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	case err != sql.ErrNoRows:
+		tx.Rollback()
+		return nil, err
+	}
+
+	res, err := tx.Exec(insertUserQuery, insertArgs(user)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if _, err := tx.Exec(insertIdempotencyKeyQuery, key, id, time.Now().Add(idempotencyKeyTTL)); err != nil {
+		// A concurrent retry may have recorded the key after our lookup, so
+		// undo our insert and replay theirs if the key is there now
+		tx.Rollback()
+		var winnerID int64
+		if s.db.QueryRow(selectIdempotencyKeyQuery, key).Scan(&winnerID, &expiresAt) == nil &&
+			time.Now().Before(expiresAt) {
+			return s.GetByID(winnerID)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	inserted := *user
+	inserted.ID = id
+	return &inserted, nil
+}
@@ -0,0 +1,258 @@
+package users
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestInsertIdempotent is a test function for the SQLStore's InsertIdempotent
+func TestInsertIdempotent(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name         string
+		keyExpiresAt *time.Time
+		expectedUser *User
+	}{
+		{
+			"First Insert",
+			nil,
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+		},
+		{
+			"Duplicate Key Replay",
+			func() *time.Time { exp := time.Now().Add(time.Hour); return &exp }(),
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+		},
+		{
+			"Expired Key Inserts Again",
+			func() *time.Time { exp := time.Now().Add(-time.Hour); return &exp }(),
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		key := "signup-key-123"
+		newUser := &User{
+			0,
+			c.expectedUser.Email,
+			c.expectedUser.PassHash,
+			c.expectedUser.UserName,
+			c.expectedUser.FirstName,
+			c.expectedUser.LastName,
+			c.expectedUser.PhotoURL,
+		}
+
+		mock.ExpectBegin()
+		keyRows := mock.NewRows([]string{"UserID", "ExpiresAt"})
+		if c.keyExpiresAt != nil {
+			keyRows.AddRow(c.expectedUser.ID, *c.keyExpiresAt)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKeyQuery)).WithArgs(key).WillReturnRows(keyRows)
+
+		if c.keyExpiresAt != nil && time.Now().Before(*c.keyExpiresAt) {
+			// The earlier user is returned through GetByID without inserting
+			mock.ExpectRollback()
+			row := mock.NewRows([]string{
+				"ID",
+				"Email",
+				"PassHash",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			).AddRow(
+				c.expectedUser.ID,
+				c.expectedUser.Email,
+				c.expectedUser.PassHash,
+				c.expectedUser.UserName,
+				c.expectedUser.FirstName,
+				c.expectedUser.LastName,
+				c.expectedUser.PhotoURL,
+			)
+			query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=?"
+			mock.ExpectQuery(query).WithArgs(c.expectedUser.ID).WillReturnRows(row)
+		} else {
+			if c.keyExpiresAt != nil {
+				mock.ExpectExec(regexp.QuoteMeta(deleteIdempotencyKeyQuery)).WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectExec(regexp.QuoteMeta(insertUserQuery)).WithArgs(
+				newUser.Email,
+				newUser.PassHash,
+				newUser.UserName,
+				newUser.FirstName,
+				newUser.LastName,
+				newUser.PhotoURL,
+			).WillReturnResult(sqlmock.NewResult(c.expectedUser.ID, 1))
+			mock.ExpectExec(regexp.QuoteMeta(insertIdempotencyKeyQuery)).
+				WithArgs(key, c.expectedUser.ID, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+
+		// Test InsertIdempotent()
+		user, err := mainSQLStore.InsertIdempotent(key, newUser)
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(user, c.expectedUser) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+// TestInsertIdempotentConcurrentRetry checks that losing the race to record
+// an idempotency key returns the winning retry's user
+func TestInsertIdempotentConcurrentRetry(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name         string
+		keyRecorded  bool
+		expectedUser *User
+		expectError  bool
+	}{
+		{
+			"Key Recorded By Concurrent Retry",
+			true,
+			&User{
+				7,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			false,
+		},
+		{
+			"Key Insert Fails For Another Reason",
+			false,
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		key := "signup-key-123"
+		newUser := &User{0, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"}
+		keyErr := errors.New("Duplicate entry 'signup-key-123' for key 'PRIMARY'")
+
+		// Both retries miss the key, so this one inserts a user
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKeyQuery)).WithArgs(key).
+			WillReturnRows(mock.NewRows([]string{"UserID", "ExpiresAt"}))
+		mock.ExpectExec(regexp.QuoteMeta(insertUserQuery)).WithArgs(
+			newUser.Email,
+			newUser.PassHash,
+			newUser.UserName,
+			newUser.FirstName,
+			newUser.LastName,
+			newUser.PhotoURL,
+		).WillReturnResult(sqlmock.NewResult(8, 1))
+
+		// ...but the other retry records the key first
+		mock.ExpectExec(regexp.QuoteMeta(insertIdempotencyKeyQuery)).
+			WithArgs(key, int64(8), sqlmock.AnyArg()).
+			WillReturnError(keyErr)
+		mock.ExpectRollback()
+
+		keyRows := mock.NewRows([]string{"UserID", "ExpiresAt"})
+		if c.keyRecorded {
+			keyRows.AddRow(c.expectedUser.ID, time.Now().Add(time.Hour))
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKeyQuery)).WithArgs(key).WillReturnRows(keyRows)
+
+		if c.expectError {
+			// Test InsertIdempotent()
+			user, err := mainSQLStore.InsertIdempotent(key, newUser)
+			if user != nil || err != keyErr {
+				t.Errorf("Expected error [%v] but got [%v] instead", keyErr, err)
+			}
+		} else {
+			// The winner's user is returned through GetByID
+			row := mock.NewRows([]string{
+				"ID",
+				"Email",
+				"PassHash",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			).AddRow(
+				c.expectedUser.ID,
+				c.expectedUser.Email,
+				c.expectedUser.PassHash,
+				c.expectedUser.UserName,
+				c.expectedUser.FirstName,
+				c.expectedUser.LastName,
+				c.expectedUser.PhotoURL,
+			)
+			query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=?"
+			mock.ExpectQuery(query).WithArgs(c.expectedUser.ID).WillReturnRows(row)
+
+			// Test InsertIdempotent()
+			user, err := mainSQLStore.InsertIdempotent(key, newUser)
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(user, c.expectedUser) {
+				t.Errorf("Error, invalid match in test [%s]", c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
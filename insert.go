@@ -0,0 +1,28 @@
+package users
+
+const insertUserQuery = "insert into Users(email,passHash,username,firstName,lastName,photoUrl) values (?,?,?,?,?,?)"
+
+// insertArgs returns the arguments for insertUserQuery in column order
+func insertArgs(user *User) []interface{} {
+	return []interface{}{
+		user.Email,
+		user.PassHash,
+		user.UserName,
+		user.FirstName,
+		user.LastName,
+		user.PhotoURL,
+	}
+}
+
+// InsertID inserts the given user into the store and returns only
+// the newly-assigned ID, without building a new User
+func (s *SQLStore) InsertID(user *User) (int64, error) {
+	if err := validateLengths(user); err != nil {
+		return 0, err
+	}
+	res, err := s.db.Exec(insertUserQuery, insertArgs(user)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
@@ -0,0 +1,112 @@
+package users
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestInsertID is a test function for the SQLStore's InsertID
+func TestInsertID(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name        string
+		user        *User
+		expectedID  int64
+		expectError bool
+	}{
+		{
+			"Successful Insert",
+			&User{
+				0,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			1,
+			false,
+		},
+		{
+			"Successful Insert With Large ID",
+			&User{
+				0,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			1234567890,
+			false,
+		},
+		{
+			"Failed Insert",
+			&User{
+				0,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			0,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		query := regexp.QuoteMeta(insertUserQuery)
+		expectedExec := mock.ExpectExec(query).WithArgs(
+			c.user.Email,
+			c.user.PassHash,
+			c.user.UserName,
+			c.user.FirstName,
+			c.user.LastName,
+			c.user.PhotoURL,
+		)
+
+		if c.expectError {
+			// Set up expected exec that will return an error
+			insertErr := errors.New("insert failed")
+			expectedExec.WillReturnError(insertErr)
+
+			// Test InsertID()
+			id, err := mainSQLStore.InsertID(c.user)
+			if id != 0 || err == nil {
+				t.Errorf("Expected error [%v] but got [%v] instead", insertErr, err)
+			}
+		} else {
+			// Set up expected exec that reports the new ID as the last insert ID
+			expectedExec.WillReturnResult(sqlmock.NewResult(c.expectedID, 1))
+
+			// Test InsertID()
+			id, err := mainSQLStore.InsertID(c.user)
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if id != c.expectedID {
+				t.Errorf("Error, expected ID [%d] but got [%d] in test [%s]", c.expectedID, id, c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
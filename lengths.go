@@ -0,0 +1,31 @@
+package users
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// MaxEmailLength is the longest email address allowed, in bytes, per RFC 5321
+const MaxEmailLength = 254
+
+// MaxUserNameLength is the longest username allowed, in characters. Set it
+// to match the size of the username column in your schema
+var MaxUserNameLength = 255
+
+// ErrEmailTooLong is returned when an email is longer than MaxEmailLength
+var ErrEmailTooLong = errors.New("email is too long")
+
+// ErrUserNameTooLong is returned when a username is longer than MaxUserNameLength
+var ErrUserNameTooLong = errors.New("username is too long")
+
+// validateLengths checks the user's email and username against the maximum
+// lengths so over-long input fails with a clear error before reaching the DB
+func validateLengths(user *User) error {
+	if len(user.Email) > MaxEmailLength {
+		return ErrEmailTooLong
+	}
+	if utf8.RuneCountInString(user.UserName) > MaxUserNameLength {
+		return ErrUserNameTooLong
+	}
+	return nil
+}
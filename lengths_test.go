@@ -0,0 +1,84 @@
+package users
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestValidateLengths is a test function for the length checks done before
+// writing a user
+func TestValidateLengths(t *testing.T) {
+	longLocal := strings.Repeat("a", MaxEmailLength-len("@test.com")+1)
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		email         string
+		userName      string
+		expectedError error
+	}{
+		{
+			"Valid Lengths",
+			"test@test.com",
+			"username",
+			nil,
+		},
+		{
+			"Email At Maximum Length",
+			longLocal[1:] + "@test.com",
+			"username",
+			nil,
+		},
+		{
+			"Email Too Long",
+			longLocal + "@test.com",
+			"username",
+			ErrEmailTooLong,
+		},
+		{
+			"UserName At Maximum Length",
+			"test@test.com",
+			strings.Repeat("é", MaxUserNameLength),
+			nil,
+		},
+		{
+			"UserName Too Long",
+			"test@test.com",
+			strings.Repeat("a", MaxUserNameLength+1),
+			ErrUserNameTooLong,
+		},
+	}
+
+	for _, c := range cases {
+		user := &User{0, c.email, []byte("passhash123"), c.userName, "firstname", "lastname", "photourl"}
+		if err := validateLengths(user); err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+	}
+
+	// Writers should fail fast without sending anything to the DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("There was a problem opening a database connection: [%v]", err)
+	}
+	defer db.Close()
+
+	mainSQLStore := &SQLStore{db}
+	user := &User{1, longLocal + "@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"}
+
+	if _, err := mainSQLStore.InsertID(user); err != ErrEmailTooLong {
+		t.Errorf("Expected error [%v] from InsertID but got [%v] instead", ErrEmailTooLong, err)
+	}
+	if _, err := mainSQLStore.InsertIdempotent("key", user); err != ErrEmailTooLong {
+		t.Errorf("Expected error [%v] from InsertIdempotent but got [%v] instead", ErrEmailTooLong, err)
+	}
+	if _, err := mainSQLStore.Replace(user); err != ErrEmailTooLong {
+		t.Errorf("Expected error [%v] from Replace but got [%v] instead", ErrEmailTooLong, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
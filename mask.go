@@ -0,0 +1,20 @@
+package users
+
+import "strings"
+
+// MaskEmail returns a version of the email that is safe to put in logs and
+// error messages, keeping only the first character of the local part and
+// the domain, e.g. "j***@example.com". Local parts of a single character are
+// masked entirely, and anything that doesn't look like an email becomes "***"
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "***"
+	}
+	local := []rune(email[:at])
+	domain := email[at+1:]
+	if len(local) == 1 {
+		return "***@" + domain
+	}
+	return string(local[0]) + "***@" + domain
+}
@@ -0,0 +1,55 @@
+package users
+
+import "testing"
+
+// TestMaskEmail is a test function for MaskEmail
+func TestMaskEmail(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name     string
+		email    string
+		expected string
+	}{
+		{
+			"Typical Email",
+			"john@example.com",
+			"j***@example.com",
+		},
+		{
+			"Single Character Local Part",
+			"j@example.com",
+			"***@example.com",
+		},
+		{
+			"Multibyte Local Part",
+			"émile@example.com",
+			"é***@example.com",
+		},
+		{
+			"Missing At Sign",
+			"john.example.com",
+			"***",
+		},
+		{
+			"Empty Local Part",
+			"@example.com",
+			"***",
+		},
+		{
+			"Empty Domain",
+			"john@",
+			"***",
+		},
+		{
+			"Empty String",
+			"",
+			"***",
+		},
+	}
+
+	for _, c := range cases {
+		if result := MaskEmail(c.email); result != c.expected {
+			t.Errorf("Error, expected [%s] but got [%s] in test [%s]", c.expected, result, c.name)
+		}
+	}
+}
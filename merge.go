@@ -0,0 +1,63 @@
+package users
+
+import "errors"
+
+// MergeReassignments are the statements MergeUsers runs to move data owned by
+// the duplicate user over to the primary user. Each statement takes the
+// primary ID then the duplicate ID, e.g.
+//
+//	"update Posts set userID=? where userID=?"
+var MergeReassignments []string
+
+// ErrMergeSameUser is returned when asked to merge a user into itself
+var ErrMergeSameUser = errors.New("cannot merge a user into itself")
+
+const lockMergeUsersQuery = "select id from Users where id in (?,?) for update"
+
+// MergeUsers moves everything owned by the duplicate user to the primary user
+// by running MergeReassignments, then deletes the duplicate, all in one
+// transaction. If any statement fails nothing is changed. Returns
+// ErrUserNotFound if either user doesn't exist
+func (s *SQLStore) MergeUsers(primaryID, duplicateID int64) error {
+	if primaryID == duplicateID {
+		return ErrMergeSameUser
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Lock both rows so neither user can be deleted while the merge runs
+	rows, err := tx.Query(lockMergeUsersQuery, primaryID, duplicateID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	found := 0
+	for rows.Next() {
+		found++
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if found != 2 {
+		tx.Rollback()
+		return ErrUserNotFound
+	}
+
+	for _, stmt := range MergeReassignments {
+		if _, err := tx.Exec(stmt, primaryID, duplicateID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(deleteUserQuery, duplicateID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,106 @@
+package users
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestMergeUsers is a test function for the SQLStore's MergeUsers
+func TestMergeUsers(t *testing.T) {
+	defer func(stmts []string) { MergeReassignments = stmts }(MergeReassignments)
+	MergeReassignments = []string{
+		"update Posts set userID=? where userID=?",
+		"update Comments set userID=? where userID=?",
+	}
+	reassignErr := errors.New("reassignment failed")
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		usersFound    int
+		failingStmt   int
+		expectedError error
+	}{
+		{
+			"Successful Merge",
+			2,
+			-1,
+			nil,
+		},
+		{
+			"Reassignment Fails",
+			2,
+			1,
+			reassignErr,
+		},
+		{
+			"Duplicate Not Found",
+			1,
+			-1,
+			ErrUserNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+		var primaryID, duplicateID int64 = 1, 2
+
+		mock.ExpectBegin()
+		rows := mock.NewRows([]string{"ID"})
+		for i := 0; i < c.usersFound; i++ {
+			rows.AddRow(int64(i + 1))
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(lockMergeUsersQuery)).WithArgs(primaryID, duplicateID).WillReturnRows(rows)
+
+		if c.usersFound == 2 {
+			for i, stmt := range MergeReassignments {
+				exec := mock.ExpectExec(regexp.QuoteMeta(stmt)).WithArgs(primaryID, duplicateID)
+				if i == c.failingStmt {
+					exec.WillReturnError(reassignErr)
+					break
+				}
+				exec.WillReturnResult(sqlmock.NewResult(0, 3))
+			}
+		}
+
+		if c.expectedError == nil {
+			mock.ExpectExec(regexp.QuoteMeta(deleteUserQuery)).WithArgs(duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		} else {
+			// Nothing is kept when any step fails
+			mock.ExpectRollback()
+		}
+
+		// Test MergeUsers()
+		if err := mainSQLStore.MergeUsers(primaryID, duplicateID); err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+
+	// Merging a user into itself never touches the DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("There was a problem opening a database connection: [%v]", err)
+	}
+	defer db.Close()
+	if err := (&SQLStore{db}).MergeUsers(1, 1); err != ErrMergeSameUser {
+		t.Errorf("Expected error [%v] but got [%v] instead", ErrMergeSameUser, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,63 @@
+package users
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bindNamed turns a query template containing :name tokens into a query
+// with positional ? placeholders, returning the values from params in the
+// order the placeholders appear. A name may be used more than once. Text
+// inside single-quoted literals and :: casts are left untouched. Returns an
+// error if the template uses a name that isn't in params
+func bindNamed(template string, params map[string]interface{}) (string, []interface{}, error) {
+	var query strings.Builder
+	var args []interface{}
+	inLiteral := false
+
+	for i := 0; i < len(template); i++ {
+		ch := template[i]
+		switch {
+		case ch == '\'':
+			inLiteral = !inLiteral
+			query.WriteByte(ch)
+		case inLiteral || ch != ':':
+			query.WriteByte(ch)
+		case i+1 < len(template) && template[i+1] == ':':
+			// A :: cast, not a parameter
+			query.WriteString("::")
+			i++
+		default:
+			end := i + 1
+			for end < len(template) && isNameChar(template[end], end == i+1) {
+				end++
+			}
+			if end == i+1 {
+				query.WriteByte(ch)
+				continue
+			}
+			name := template[i+1 : end]
+			value, found := params[name]
+			if !found {
+				return "", nil, fmt.Errorf("error binding query: no value for parameter :%s", name)
+			}
+			query.WriteByte('?')
+			args = append(args, value)
+			i = end - 1
+		}
+	}
+	return query.String(), args, nil
+}
+
+// isNameChar reports whether c can appear in a parameter name. Names
+// start with a letter or underscore and may then contain digits
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+package users
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBindNamed is a test function for bindNamed
+func TestBindNamed(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		template      string
+		params        map[string]interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+		expectError   bool
+	}{
+		{
+			"Single Parameter",
+			"select id from Users where id=:id",
+			map[string]interface{}{"id": int64(1)},
+			"select id from Users where id=?",
+			[]interface{}{int64(1)},
+			false,
+		},
+		{
+			"Parameters Out Of Map Order",
+			"update Users set lastName=:last,firstName=:first where id=:id",
+			map[string]interface{}{"id": int64(1), "first": "firstname", "last": "lastname"},
+			"update Users set lastName=?,firstName=? where id=?",
+			[]interface{}{"lastname", "firstname", int64(1)},
+			false,
+		},
+		{
+			"Repeated Parameter",
+			"select id from Users where email=:q or username=:q",
+			map[string]interface{}{"q": "test"},
+			"select id from Users where email=? or username=?",
+			[]interface{}{"test", "test"},
+			false,
+		},
+		{
+			"Literal And Cast Untouched",
+			"select id::text from Users where firstName=':notparam' and id=:user_id1",
+			map[string]interface{}{"user_id1": int64(2)},
+			"select id::text from Users where firstName=':notparam' and id=?",
+			[]interface{}{int64(2)},
+			false,
+		},
+		{
+			"No Parameters",
+			"select count(*) from Users",
+			nil,
+			"select count(*) from Users",
+			nil,
+			false,
+		},
+		{
+			"Missing Parameter",
+			"select id from Users where id=:id",
+			map[string]interface{}{"ID": int64(1)},
+			"",
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		query, args, err := bindNamed(c.template, c.params)
+		if c.expectError {
+			if err == nil {
+				t.Errorf("Expected an error but got none in test [%s]", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if query != c.expectedQuery {
+			t.Errorf("Error, expected query [%s] but got [%s] in test [%s]", c.expectedQuery, query, c.name)
+		}
+		if !reflect.DeepEqual(args, c.expectedArgs) {
+			t.Errorf("Error, expected args [%v] but got [%v] in test [%s]", c.expectedArgs, args, c.name)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package users
+
+import "strings"
+
+// GetByIDsOrdered returns the users with the given IDs in the same order as
+// ids, using a single query. An ID with no matching user has a nil entry at
+// its position, so the result always lines up with the input
+func (s *SQLStore) GetByIDsOrdered(ids []int64) ([]*User, error) {
+	ordered := make([]*User, len(ids))
+	if len(ids) == 0 {
+		return ordered, nil
+	}
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id in (?" +
+		strings.Repeat(",?", len(ids)-1) + ")"
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[int64]*User, len(ids))
+	for rows.Next() {
+		user := &User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.PassHash,
+			&user.UserName,
+			&user.FirstName,
+			&user.LastName,
+			&user.PhotoURL,
+		); err != nil {
+			return nil, err
+		}
+		found[user.ID] = user
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		ordered[i] = found[id]
+	}
+	return ordered, nil
+}
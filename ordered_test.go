@@ -0,0 +1,103 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestGetByIDsOrdered is a test function for the SQLStore's GetByIDsOrdered
+func TestGetByIDsOrdered(t *testing.T) {
+	users := map[int64]*User{
+		1: {1, "one@test.com", []byte("passhash1"), "one", "firstname", "lastname", "photourl"},
+		2: {2, "two@test.com", []byte("passhash2"), "two", "firstname", "lastname", "photourl"},
+		3: {3, "three@test.com", []byte("passhash3"), "three", "firstname", "lastname", "photourl"},
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		idsToGet      []int64
+		returnedIDs   []int64
+		query         string
+		expectedUsers []*User
+	}{
+		{
+			"Order Preserved",
+			[]int64{3, 1, 2},
+			[]int64{1, 2, 3},
+			"select id,email,passHash,username,firstName,lastName,photoUrl from Users where id in (?,?,?)",
+			[]*User{users[3], users[1], users[2]},
+		},
+		{
+			"Missing ID Is Nil",
+			[]int64{2, 99, 1},
+			[]int64{1, 2},
+			"select id,email,passHash,username,firstName,lastName,photoUrl from Users where id in (?,?,?)",
+			[]*User{users[2], nil, users[1]},
+		},
+		{
+			"Repeated ID",
+			[]int64{1, 1},
+			[]int64{1},
+			"select id,email,passHash,username,firstName,lastName,photoUrl from Users where id in (?,?)",
+			[]*User{users[1], users[1]},
+		},
+		{
+			"Empty Slice",
+			[]int64{},
+			nil,
+			"",
+			[]*User{},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		// No query is expected for an empty slice
+		if len(c.idsToGet) > 0 {
+			args := make([]driver.Value, 0, len(c.idsToGet))
+			for _, id := range c.idsToGet {
+				args = append(args, id)
+			}
+			rows := mock.NewRows([]string{
+				"ID",
+				"Email",
+				"PassHash",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			)
+			for _, id := range c.returnedIDs {
+				u := users[id]
+				rows.AddRow(u.ID, u.Email, u.PassHash, u.UserName, u.FirstName, u.LastName, u.PhotoURL)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(c.query)).WithArgs(args...).WillReturnRows(rows)
+		}
+
+		// Test GetByIDsOrdered()
+		ordered, err := mainSQLStore.GetByIDsOrdered(c.idsToGet)
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(ordered, c.expectedUsers) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrInvalidCredentials is returned when a password doesn't match the stored hash
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+const selectPassHashQuery = "select passHash from Users where id=?"
+
+// VerifyPassword checks the password against the stored hash of the user with
+// the given ID using PasswordHasher, fetching only the passHash column.
+// Returns ErrUserNotFound if there is no such user and ErrInvalidCredentials
+// if the password is wrong
+func (s *SQLStore) VerifyPassword(id int64, password string) error {
+	var passHash []byte
+	err := s.db.QueryRow(selectPassHashQuery, id).Scan(&passHash)
+	if err == sql.ErrNoRows {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := PasswordHasher.Compare(passHash, password); err != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package users
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestVerifyPassword is a test function for the SQLStore's VerifyPassword
+func TestVerifyPassword(t *testing.T) {
+	passHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("There was a problem generating a password hash: [%v]", err)
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		userExists    bool
+		hashAsText    bool
+		password      string
+		expectedError error
+	}{
+		{
+			"Correct Password",
+			true,
+			false,
+			"password123",
+			nil,
+		},
+		{
+			"Correct Password With TEXT Hash Column",
+			true,
+			true,
+			"password123",
+			nil,
+		},
+		{
+			"Wrong Password",
+			true,
+			false,
+			"wrongpassword",
+			ErrInvalidCredentials,
+		},
+		{
+			"User Not Found",
+			false,
+			false,
+			"password123",
+			ErrUserNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		// Only the passHash column is selected
+		row := mock.NewRows([]string{"PassHash"})
+		if c.userExists && c.hashAsText {
+			// Drivers return VARCHAR/TEXT columns as strings
+			row.AddRow(string(passHash))
+		} else if c.userExists {
+			row.AddRow(passHash)
+		}
+		mock.ExpectQuery("^" + regexp.QuoteMeta(selectPassHashQuery) + "$").WithArgs(int64(1)).WillReturnRows(row)
+
+		// Test VerifyPassword()
+		if err := mainSQLStore.VerifyPassword(1, c.password); err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
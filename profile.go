@@ -0,0 +1,35 @@
+package users
+
+import "database/sql"
+
+// PublicProfile is the subset of a User that is safe to show to other users.
+// It deliberately has no Email or PassHash
+type PublicProfile struct {
+	ID        int64
+	UserName  string
+	FirstName string
+	LastName  string
+	PhotoURL  string
+}
+
+const selectPublicProfileQuery = "select id,username,firstName,lastName,photoUrl from Users where id=?"
+
+// GetPublicProfile returns the public profile of the user with the given ID,
+// selecting only the public columns so the email is never fetched
+func (s *SQLStore) GetPublicProfile(id int64) (*PublicProfile, error) {
+	p := &PublicProfile{}
+	err := s.db.QueryRow(selectPublicProfileQuery, id).Scan(
+		&p.ID,
+		&p.UserName,
+		&p.FirstName,
+		&p.LastName,
+		&p.PhotoURL,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,101 @@
+package users
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestGetPublicProfile is a test function for the SQLStore's GetPublicProfile
+func TestGetPublicProfile(t *testing.T) {
+	// The profile query must never touch private columns
+	for _, column := range []string{"email", "passHash"} {
+		if strings.Contains(selectPublicProfileQuery, column) {
+			t.Errorf("Error, public profile query selects private column [%s]", column)
+		}
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name            string
+		expectedProfile *PublicProfile
+		idToGet         int64
+		expectError     bool
+	}{
+		{
+			"Profile Found",
+			&PublicProfile{
+				1,
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			1,
+			false,
+		},
+		{
+			"Profile Not Found",
+			&PublicProfile{},
+			2,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		query := regexp.QuoteMeta(selectPublicProfileQuery)
+
+		if c.expectError {
+			// Set up expected query that returns no rows
+			row := mock.NewRows([]string{"ID", "UserName", "FirstName", "LastName", "PhotoURL"})
+			mock.ExpectQuery(query).WithArgs(c.idToGet).WillReturnRows(row)
+
+			// Test GetPublicProfile()
+			profile, err := mainSQLStore.GetPublicProfile(c.idToGet)
+			if profile != nil || err != ErrUserNotFound {
+				t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+			}
+		} else {
+			// Set up an expected query with only the public columns
+			row := mock.NewRows([]string{
+				"ID",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			).AddRow(
+				c.expectedProfile.ID,
+				c.expectedProfile.UserName,
+				c.expectedProfile.FirstName,
+				c.expectedProfile.LastName,
+				c.expectedProfile.PhotoURL,
+			)
+			mock.ExpectQuery(query).WithArgs(c.idToGet).WillReturnRows(row)
+
+			// Test GetPublicProfile()
+			profile, err := mainSQLStore.GetPublicProfile(c.idToGet)
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(profile, c.expectedProfile) {
+				t.Errorf("Error, invalid match in test [%s]", c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
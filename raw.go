@@ -0,0 +1,41 @@
+package users
+
+const selectRawByIDQuery = "select * from Users where id=?"
+
+// GetRawByID returns every column of the user with the given ID as a map of
+// column name to the value the driver returned, including columns User doesn't
+// model. NULL columns are nil entries. Meant for diagnosing schema drift.
+// Returns ErrUserNotFound if there is no user with the ID
+func (s *SQLStore) GetRawByID(id int64) (map[string]interface{}, error) {
+	rows, err := s.db.Query(selectRawByIDQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrUserNotFound
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		raw[column] = values[i]
+	}
+	return raw, nil
+}
@@ -0,0 +1,83 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestGetRawByID is a test function for the SQLStore's GetRawByID
+func TestGetRawByID(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name        string
+		columns     []string
+		values      []driver.Value
+		expectedRaw map[string]interface{}
+		expectError bool
+	}{
+		{
+			"Row With Extra Columns",
+			[]string{"id", "email", "passHash", "username", "firstName", "lastName", "photoUrl", "tenantID", "legacyFlag"},
+			[]driver.Value{int64(1), "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl", int64(42), nil},
+			map[string]interface{}{
+				"id":         int64(1),
+				"email":      "test@test.com",
+				"passHash":   []byte("passhash123"),
+				"username":   "username",
+				"firstName":  "firstname",
+				"lastName":   "lastname",
+				"photoUrl":   "photourl",
+				"tenantID":   int64(42),
+				"legacyFlag": nil,
+			},
+			false,
+		},
+		{
+			"User Not Found",
+			[]string{"id", "email"},
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		rows := mock.NewRows(c.columns)
+		if c.values != nil {
+			rows.AddRow(c.values...)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(selectRawByIDQuery)).WithArgs(int64(1)).WillReturnRows(rows)
+
+		// Test GetRawByID()
+		raw, err := mainSQLStore.GetRawByID(1)
+		if c.expectError {
+			if raw != nil || err != ErrUserNotFound {
+				t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(raw, c.expectedRaw) {
+				t.Errorf("Error, expected [%v] but got [%v] in test [%s]", c.expectedRaw, raw, c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
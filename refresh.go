@@ -0,0 +1,13 @@
+package users
+
+// Refresh re-reads the user with u.ID and overwrites the fields of u in place
+// with the stored values. If the user can't be read, u is left unchanged and
+// the error from GetByID is returned (ErrUserNotFound if the row is gone)
+func (s *SQLStore) Refresh(u *User) error {
+	current, err := s.GetByID(u.ID)
+	if err != nil {
+		return err
+	}
+	*u = *current
+	return nil
+}
@@ -0,0 +1,89 @@
+package users
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestRefresh is a test function for the SQLStore's Refresh
+func TestRefresh(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name         string
+		storedUser   *User
+		expectedUser *User
+		expectError  bool
+	}{
+		{
+			"User Refreshed",
+			&User{1, "new@test.com", []byte("passhash456"), "newusername", "newfirst", "newlast", "newphoto"},
+			&User{1, "new@test.com", []byte("passhash456"), "newusername", "newfirst", "newlast", "newphoto"},
+			false,
+		},
+		{
+			"User Gone",
+			nil,
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		// The long-lived copy of the user being refreshed
+		user := &User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"}
+
+		query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=?"
+
+		if c.expectError {
+			mock.ExpectQuery(query).WithArgs(user.ID).WillReturnError(ErrUserNotFound)
+
+			// Test Refresh()
+			if err := mainSQLStore.Refresh(user); err != ErrUserNotFound {
+				t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+			}
+		} else {
+			row := mock.NewRows([]string{
+				"ID",
+				"Email",
+				"PassHash",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			).AddRow(
+				c.storedUser.ID,
+				c.storedUser.Email,
+				c.storedUser.PassHash,
+				c.storedUser.UserName,
+				c.storedUser.FirstName,
+				c.storedUser.LastName,
+				c.storedUser.PhotoURL,
+			)
+			mock.ExpectQuery(query).WithArgs(user.ID).WillReturnRows(row)
+
+			// Test Refresh()
+			if err := mainSQLStore.Refresh(user); err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+		}
+
+		if !reflect.DeepEqual(user, c.expectedUser) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
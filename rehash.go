@@ -0,0 +1,34 @@
+package users
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptCost is the bcrypt cost new password hashes should be generated with.
+// BcryptHasher refuses to hash while it is out of range, and CheckBcryptCost
+// can be called at startup to catch a bad setting early
+var BcryptCost = bcrypt.DefaultCost
+
+// CheckBcryptCost returns an error if BcryptCost is outside the range bcrypt
+// accepts, so a bad setting fails at startup instead of on the first signup
+func CheckBcryptCost() error {
+	if BcryptCost < bcrypt.MinCost || BcryptCost > bcrypt.MaxCost {
+		return fmt.Errorf("bcrypt cost %d is out of range: must be between %d and %d",
+			BcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return nil
+}
+
+// NeedsRehash reports whether the user's stored password hash should be
+// regenerated the next time the user's plaintext password is available. The
+// decision belongs to PasswordHasher: if it implements RehashChecker (as
+// BcryptHasher does) its answer is used, otherwise hashes are never reported
+// as needing a rehash, since a hasher that can't say can't judge its own hashes
+func NeedsRehash(u *User) bool {
+	if checker, ok := PasswordHasher.(RehashChecker); ok {
+		return checker.NeedsRehash(u.PassHash)
+	}
+	return false
+}
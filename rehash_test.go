@@ -0,0 +1,136 @@
+package users
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestNeedsRehash is a test function for NeedsRehash
+func TestNeedsRehash(t *testing.T) {
+	hashAtCost := func(cost int) []byte {
+		hash, err := bcrypt.GenerateFromPassword([]byte("password123"), cost)
+		if err != nil {
+			t.Fatalf("There was a problem generating a hash at cost %d: [%v]", cost, err)
+		}
+		return hash
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name     string
+		passHash []byte
+		expected bool
+	}{
+		{
+			"Hash At Configured Cost",
+			hashAtCost(BcryptCost),
+			false,
+		},
+		{
+			"Hash At Lower Cost",
+			hashAtCost(bcrypt.MinCost),
+			true,
+		},
+		{
+			"Hash At Higher Cost",
+			hashAtCost(BcryptCost + 1),
+			true,
+		},
+		{
+			"Not A Bcrypt Hash",
+			[]byte("passhash123"),
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		user := &User{1, "test@test.com", c.passHash, "username", "firstname", "lastname", "photourl"}
+		if result := NeedsRehash(user); result != c.expected {
+			t.Errorf("Error, expected [%t] but got [%t] in test [%s]", c.expected, result, c.name)
+		}
+	}
+}
+
+// TestCheckBcryptCost is a test function for CheckBcryptCost
+func TestCheckBcryptCost(t *testing.T) {
+	defer func(cost int) { BcryptCost = cost }(BcryptCost)
+
+	// Create a slice of test cases
+	cases := []struct {
+		name        string
+		cost        int
+		expectError bool
+	}{
+		{"Default Cost", bcrypt.DefaultCost, false},
+		{"Minimum Cost", bcrypt.MinCost, false},
+		{"Maximum Cost", bcrypt.MaxCost, false},
+		{"Below Minimum", bcrypt.MinCost - 1, true},
+		{"Above Maximum", bcrypt.MaxCost + 1, true},
+		{"Zero", 0, true},
+	}
+
+	for _, c := range cases {
+		BcryptCost = c.cost
+		err := CheckBcryptCost()
+		if c.expectError && err == nil {
+			t.Errorf("Expected an error but got none in test [%s]", c.name)
+		}
+		if !c.expectError && err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+	}
+}
+
+// rehashingFakeHasher is a fakeHasher that also decides when hashes need rehashing
+type rehashingFakeHasher struct {
+	fakeHasher
+}
+
+func (f *rehashingFakeHasher) NeedsRehash(hash []byte) bool {
+	return string(hash) == "old:password123"
+}
+
+// TestNeedsRehashUsesHasher checks that NeedsRehash defers to PasswordHasher
+func TestNeedsRehashUsesHasher(t *testing.T) {
+	defer func(h Hasher) { PasswordHasher = h }(PasswordHasher)
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("There was a problem generating a hash: [%v]", err)
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name     string
+		hasher   Hasher
+		passHash []byte
+		expected bool
+	}{
+		{
+			"Hasher Reports Outdated Hash",
+			&rehashingFakeHasher{},
+			[]byte("old:password123"),
+			true,
+		},
+		{
+			"Hasher Reports Current Hash",
+			&rehashingFakeHasher{},
+			[]byte("fake:password123"),
+			false,
+		},
+		{
+			"Hasher Without RehashChecker Never Rehashes",
+			&fakeHasher{},
+			bcryptHash,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		PasswordHasher = c.hasher
+		user := &User{1, "test@test.com", c.passHash, "username", "firstname", "lastname", "photourl"}
+		if result := NeedsRehash(user); result != c.expected {
+			t.Errorf("Error, expected [%t] but got [%t] in test [%s]", c.expected, result, c.name)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package users
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// Replace overwrites the mutable fields of the stored user with the values
+// in the given user, updating only the columns that actually changed. The
+// ID is only used to find the row and is never altered, and PassHash is left
+// alone. A changed username must pass the same checks as UpdateUserName. The
+// read and update happen in one transaction with the row locked. Returns
+// ErrUserNotFound if there is no user with user.ID and ErrUserNameExists if
+// another user has the new username
+func (s *SQLStore) Replace(user *User) (*User, error) {
+	if err := validateLengths(user); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := selectUserForUpdate(tx, user.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	updated := *current
+	var columns []string
+	params := map[string]interface{}{"id": current.ID}
+	if user.Email != current.Email {
+		columns = append(columns, "email=:email")
+		params["email"] = user.Email
+		updated.Email = user.Email
+	}
+	if user.UserName != current.UserName {
+		// Renames follow the same rules as UpdateUserName
+		if err := validateUserName(user.UserName); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		var takenBy int64
+		err := tx.QueryRow(selectUserNameTakenQuery, user.UserName, current.ID).Scan(&takenBy)
+		if err == nil {
+			tx.Rollback()
+			return nil, ErrUserNameExists
+		}
+		if err != sql.ErrNoRows {
+			tx.Rollback()
+			return nil, err
+		}
+		columns = append(columns, "username=:username")
+		params["username"] = user.UserName
+		updated.UserName = user.UserName
+	}
+	if user.FirstName != current.FirstName {
+		columns = append(columns, "firstName=:firstName")
+		params["firstName"] = user.FirstName
+		updated.FirstName = user.FirstName
+	}
+	if user.LastName != current.LastName {
+		columns = append(columns, "lastName=:lastName")
+		params["lastName"] = user.LastName
+		updated.LastName = user.LastName
+	}
+	if user.PhotoURL != current.PhotoURL {
+		columns = append(columns, "photoUrl=:photoUrl")
+		params["photoUrl"] = user.PhotoURL
+		updated.PhotoURL = user.PhotoURL
+	}
+
+	// Nothing changed, so there's nothing to write
+	if len(columns) == 0 {
+		tx.Rollback()
+		return current, nil
+	}
+
+	query, args, err := bindNamed("update Users set "+strings.Join(columns, ",")+" where id=:id", params)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// The row is locked, so it still exists. RowsAffected isn't checked since
+	// MySQL reports changed rows, not matched ones
+	if _, err := tx.Exec(query, args...); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
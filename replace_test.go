@@ -0,0 +1,246 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestReplace is a test function for the SQLStore's Replace
+func TestReplace(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name         string
+		currentUser  *User
+		replacement  *User
+		updateQuery  string
+		updateArgs   []driver.Value
+		rowsChanged  int64
+		expectedUser *User
+		expectError  bool
+	}{
+		{
+			"Changed Name",
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			&User{1, "test@test.com", []byte("ignored"), "username", "newfirst", "newlast", "photourl"},
+			"update Users set firstName=?,lastName=? where id=?",
+			[]driver.Value{"newfirst", "newlast", int64(1)},
+			1,
+			&User{1, "test@test.com", []byte("passhash123"), "username", "newfirst", "newlast", "photourl"},
+			false,
+		},
+		{
+			"Same Values Written Concurrently",
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			&User{1, "test@test.com", []byte("passhash123"), "username", "newfirst", "lastname", "photourl"},
+			"update Users set firstName=? where id=?",
+			[]driver.Value{"newfirst", int64(1)},
+			0,
+			&User{1, "test@test.com", []byte("passhash123"), "username", "newfirst", "lastname", "photourl"},
+			false,
+		},
+		{
+			"Nothing Changed",
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			"",
+			nil,
+			0,
+			&User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			false,
+		},
+		{
+			"User Not Found",
+			nil,
+			&User{2, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"},
+			"",
+			nil,
+			0,
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		// Replace first locks and reads the current row
+		getQuery := regexp.QuoteMeta(selectUserForUpdateQuery)
+		columns := []string{
+			"ID",
+			"Email",
+			"PassHash",
+			"UserName",
+			"FirstName",
+			"LastName",
+			"PhotoURL",
+		}
+		mock.ExpectBegin()
+
+		if c.expectError {
+			mock.ExpectQuery(getQuery).WithArgs(c.replacement.ID).WillReturnRows(mock.NewRows(columns))
+			mock.ExpectRollback()
+
+			// Test Replace()
+			user, err := mainSQLStore.Replace(c.replacement)
+			if user != nil || err != ErrUserNotFound {
+				t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+			}
+		} else {
+			row := mock.NewRows(columns).AddRow(
+				c.currentUser.ID,
+				c.currentUser.Email,
+				c.currentUser.PassHash,
+				c.currentUser.UserName,
+				c.currentUser.FirstName,
+				c.currentUser.LastName,
+				c.currentUser.PhotoURL,
+			)
+			mock.ExpectQuery(getQuery).WithArgs(c.replacement.ID).WillReturnRows(row)
+
+			// Only the changed columns should be written. MySQL reports 0 rows
+			// changed when a concurrent write already set the same values
+			if len(c.updateQuery) > 0 {
+				mock.ExpectExec(regexp.QuoteMeta(c.updateQuery)).WithArgs(c.updateArgs...).WillReturnResult(sqlmock.NewResult(0, c.rowsChanged))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			// Test Replace()
+			user, err := mainSQLStore.Replace(c.replacement)
+			if err != nil {
+				t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+			}
+			if !reflect.DeepEqual(user, c.expectedUser) {
+				t.Errorf("Error, invalid match in test [%s]", c.name)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+// TestReplaceUserName checks that Replace applies UpdateUserName's rules
+// when the username changes
+func TestReplaceUserName(t *testing.T) {
+	current := &User{1, "test@test.com", []byte("passhash123"), "username", "firstname", "lastname", "photourl"}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		newUserName   string
+		checksTaken   bool
+		takenBy       int64
+		expectedUser  *User
+		expectedError error
+	}{
+		{
+			"Valid Rename",
+			"newusername",
+			true,
+			0,
+			&User{1, "test@test.com", []byte("passhash123"), "newusername", "firstname", "lastname", "photourl"},
+			nil,
+		},
+		{
+			"UserName Taken",
+			"takenusername",
+			true,
+			2,
+			nil,
+			ErrUserNameExists,
+		},
+		{
+			"UserName With Spaces",
+			"new username",
+			false,
+			0,
+			nil,
+			ErrInvalidUserName,
+		},
+		{
+			"Empty UserName",
+			"",
+			false,
+			0,
+			nil,
+			ErrInvalidUserName,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		mock.ExpectBegin()
+		row := mock.NewRows([]string{
+			"ID",
+			"Email",
+			"PassHash",
+			"UserName",
+			"FirstName",
+			"LastName",
+			"PhotoURL"},
+		).AddRow(
+			current.ID,
+			current.Email,
+			current.PassHash,
+			current.UserName,
+			current.FirstName,
+			current.LastName,
+			current.PhotoURL,
+		)
+		mock.ExpectQuery(regexp.QuoteMeta(selectUserForUpdateQuery)).WithArgs(current.ID).WillReturnRows(row)
+
+		if c.checksTaken {
+			takenRows := mock.NewRows([]string{"ID"})
+			if c.takenBy != 0 {
+				takenRows.AddRow(c.takenBy)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(selectUserNameTakenQuery)).WithArgs(c.newUserName, current.ID).WillReturnRows(takenRows)
+		}
+		if c.expectedError == nil {
+			mock.ExpectExec(regexp.QuoteMeta("update Users set username=? where id=?")).
+				WithArgs(c.newUserName, current.ID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		} else {
+			// Nothing is written when the new username is rejected
+			mock.ExpectRollback()
+		}
+
+		// Test Replace()
+		replacement := *current
+		replacement.UserName = c.newUserName
+		user, err := mainSQLStore.Replace(&replacement)
+		if err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+		if !reflect.DeepEqual(user, c.expectedUser) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations in test [%s]: %s", c.name, err)
+		}
+	}
+}
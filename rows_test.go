@@ -0,0 +1,103 @@
+package users
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestRowsClosedOnFailure checks that every multi-row method closes its rows
+// when scanning fails partway through or the rows report an error
+func TestRowsClosedOnFailure(t *testing.T) {
+	rowErr := errors.New("connection lost mid-result")
+
+	// Create a slice of test cases
+	cases := []struct {
+		name  string
+		setup func(mock sqlmock.Sqlmock)
+		call  func(s *SQLStore) error
+	}{
+		{
+			"CountByDomain Scan Error",
+			func(mock sqlmock.Sqlmock) {
+				rows := mock.NewRows([]string{"Domain", "Count"}).
+					AddRow("test.com", int64(3)).
+					AddRow("example.com", "not a number")
+				mock.ExpectQuery(regexp.QuoteMeta(countByDomainQuery)).WillReturnRows(rows).RowsWillBeClosed()
+			},
+			func(s *SQLStore) error {
+				_, err := s.CountByDomain()
+				return err
+			},
+		},
+		{
+			"CountByDomain Row Error",
+			func(mock sqlmock.Sqlmock) {
+				rows := mock.NewRows([]string{"Domain", "Count"}).
+					AddRow("test.com", int64(3)).
+					AddRow("example.com", int64(1)).
+					RowError(1, rowErr)
+				mock.ExpectQuery(regexp.QuoteMeta(countByDomainQuery)).WillReturnRows(rows).RowsWillBeClosed()
+			},
+			func(s *SQLStore) error {
+				_, err := s.CountByDomain()
+				return err
+			},
+		},
+		{
+			"FindDuplicateEmails Scan Error In Follow-Up",
+			func(mock sqlmock.Sqlmock) {
+				emailRows := mock.NewRows([]string{"Email"}).AddRow("a@test.com")
+				mock.ExpectQuery(regexp.QuoteMeta(selectDuplicateEmailsQuery)).WillReturnRows(emailRows).RowsWillBeClosed()
+				idRows := mock.NewRows([]string{"Email", "ID"}).
+					AddRow("a@test.com", int64(1)).
+					AddRow("a@test.com", "not a number")
+				mock.ExpectQuery(regexp.QuoteMeta("select email,id from Users where email in (?) order by id")).
+					WithArgs("a@test.com").
+					WillReturnRows(idRows).
+					RowsWillBeClosed()
+			},
+			func(s *SQLStore) error {
+				_, err := s.FindDuplicateEmails()
+				return err
+			},
+		},
+		{
+			"FindDuplicateEmails Row Error",
+			func(mock sqlmock.Sqlmock) {
+				emailRows := mock.NewRows([]string{"Email"}).
+					AddRow("a@test.com").
+					AddRow("b@test.com").
+					RowError(1, rowErr)
+				mock.ExpectQuery(regexp.QuoteMeta(selectDuplicateEmailsQuery)).WillReturnRows(emailRows).RowsWillBeClosed()
+			},
+			func(s *SQLStore) error {
+				_, err := s.FindDuplicateEmails()
+				return err
+			},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+		c.setup(mock)
+
+		if err := c.call(mainSQLStore); err == nil {
+			t.Errorf("Expected an error but got none in test [%s]", c.name)
+		}
+
+		// This fails if any rows were left open
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations in test [%s]: %s", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package users
+
+import (
+	"fmt"
+	"strings"
+)
+
+const selectColumnsQuery = "select column_name,data_type from information_schema.columns where table_schema=database() and table_name=?"
+
+// expectedColumn is a column the store's queries rely on, along with the
+// data types it can be declared as
+type expectedColumn struct {
+	name  string
+	types []string
+}
+
+var textTypes = []string{"char", "varchar", "tinytext", "text", "mediumtext", "longtext"}
+
+// expectedColumns are the Users columns the store reads and writes
+var expectedColumns = []expectedColumn{
+	{"id", []string{"int", "bigint"}},
+	{"email", textTypes},
+	{"passHash", append([]string{"binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob"}, textTypes...)},
+	{"username", textTypes},
+	{"firstName", textTypes},
+	{"lastName", textTypes},
+	{"photoUrl", textTypes},
+}
+
+// VerifySchema checks that the Users table in the current database has every
+// column the store uses, with a compatible type. The returned error lists
+// all missing and mismatched columns so misconfiguration is caught at startup
+func (s *SQLStore) VerifySchema() error {
+	rows, err := s.db.Query(selectColumnsQuery, "Users")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Column names are case-insensitive in MySQL
+	actual := make(map[string]string)
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return err
+		}
+		actual[strings.ToLower(name)] = strings.ToLower(dataType)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var missing, mismatched []string
+	for _, col := range expectedColumns {
+		dataType, found := actual[strings.ToLower(col.name)]
+		if !found {
+			missing = append(missing, col.name)
+			continue
+		}
+		if !containsString(col.types, dataType) {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%s)", col.name, dataType))
+		}
+	}
+
+	if len(missing) == 0 && len(mismatched) == 0 {
+		return nil
+	}
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing columns: "+strings.Join(missing, ", "))
+	}
+	if len(mismatched) > 0 {
+		problems = append(problems, "columns with incompatible types: "+strings.Join(mismatched, ", "))
+	}
+	return fmt.Errorf("schema mismatch in table Users: %s", strings.Join(problems, "; "))
+}
+
+// containsString reports whether s is in list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package users
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestVerifySchema is a test function for the SQLStore's VerifySchema
+func TestVerifySchema(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name             string
+		columns          [][2]string
+		expectedProblems []string
+	}{
+		{
+			"Matching Schema",
+			[][2]string{
+				{"id", "int"},
+				{"email", "varchar"},
+				{"passHash", "binary"},
+				{"username", "varchar"},
+				{"firstName", "varchar"},
+				{"lastName", "varchar"},
+				{"photoUrl", "varchar"},
+			},
+			nil,
+		},
+		{
+			"Matching Schema With Other Casing And Extra Column",
+			[][2]string{
+				{"ID", "BIGINT"},
+				{"Email", "VARCHAR"},
+				{"PassHash", "CHAR"},
+				{"UserName", "VARCHAR"},
+				{"FirstName", "VARCHAR"},
+				{"LastName", "VARCHAR"},
+				{"PhotoURL", "TEXT"},
+				{"tenantID", "int"},
+			},
+			nil,
+		},
+		{
+			"Missing PhotoURL",
+			[][2]string{
+				{"id", "int"},
+				{"email", "varchar"},
+				{"passHash", "binary"},
+				{"username", "varchar"},
+				{"firstName", "varchar"},
+				{"lastName", "varchar"},
+			},
+			[]string{"missing columns: photoUrl"},
+		},
+		{
+			"Mismatched ID Type",
+			[][2]string{
+				{"id", "varchar"},
+				{"email", "varchar"},
+				{"passHash", "binary"},
+				{"username", "varchar"},
+				{"firstName", "varchar"},
+				{"lastName", "varchar"},
+				{"photoUrl", "varchar"},
+			},
+			[]string{"incompatible types: id (varchar)"},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		rows := mock.NewRows([]string{"ColumnName", "DataType"})
+		for _, col := range c.columns {
+			rows.AddRow(col[0], col[1])
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(selectColumnsQuery)).WithArgs("Users").WillReturnRows(rows)
+
+		// Test VerifySchema()
+		err = mainSQLStore.VerifySchema()
+		if len(c.expectedProblems) == 0 && err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if len(c.expectedProblems) > 0 {
+			if err == nil {
+				t.Errorf("Expected an error but got none in test [%s]", c.name)
+			} else {
+				for _, problem := range c.expectedProblems {
+					if !strings.Contains(err.Error(), problem) {
+						t.Errorf("Error, expected [%s] in error [%v] in test [%s]", problem, err, c.name)
+					}
+				}
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package users
+
+import (
+	"database/sql"
+	"log"
+)
+
+// Stats returns the connection pool statistics of the underlying database
+func (s *SQLStore) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// LogStats writes the open, in-use, and idle connection counts to the given logger
+func (s *SQLStore) LogStats(logger *log.Logger) {
+	stats := s.Stats()
+	logger.Printf("db pool: open=%d inUse=%d idle=%d waitCount=%d",
+		stats.OpenConnections,
+		stats.InUse,
+		stats.Idle,
+		stats.WaitCount,
+	)
+}
@@ -0,0 +1,37 @@
+package users
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestStats is a test function for the SQLStore's Stats and LogStats
+func TestStats(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("There was a problem opening a database connection: [%v]", err)
+	}
+	defer db.Close()
+
+	mainSQLStore := &SQLStore{db}
+
+	// Stats should be exactly what the underlying DB reports
+	stats := mainSQLStore.Stats()
+	if !reflect.DeepEqual(stats, db.Stats()) {
+		t.Errorf("Error, expected stats [%+v] but got [%+v] instead", db.Stats(), stats)
+	}
+
+	// LogStats should include the pool counts
+	var buf bytes.Buffer
+	mainSQLStore.LogStats(log.New(&buf, "", 0))
+	expected := fmt.Sprintf("open=%d inUse=%d idle=%d", stats.OpenConnections, stats.InUse, stats.Idle)
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Error, expected log line to contain [%s] but got [%s] instead", expected, buf.String())
+	}
+}
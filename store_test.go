@@ -1,6 +1,7 @@
 package users
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -125,3 +126,41 @@ func TestGetByID(t *testing.T) {
 
 	}
 }
+
+// TestGetByIDNoRows checks that GetByID reports ErrUserNotFound when the
+// query succeeds but returns no rows (sql.ErrNoRows from QueryRow.Scan)
+func TestGetByIDNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("There was a problem opening a database connection: [%v]", err)
+	}
+	defer db.Close()
+
+	// TODO: update based on the name of your type struct
+	mainSQLStore := &SQLStore{db}
+
+	// Create an empty result with the same columns as a real row
+	row := mock.NewRows([]string{
+		"ID",
+		"Email",
+		"PassHash",
+		"UserName",
+		"FirstName",
+		"LastName",
+		"PhotoURL"},
+	)
+
+	// TODO: update to match the query used in your Store implementation
+	query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=?"
+	mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(row)
+
+	// Test GetByID()
+	user, err := mainSQLStore.GetByID(2)
+	if user != nil || !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected error [%v] but got [%v] instead", ErrUserNotFound, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,148 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// ErrInvalidToken is returned when a token is unknown or has already been used
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrTokenExpired is returned when a token is found but is past its expiry
+var ErrTokenExpired = errors.New("token expired")
+
+// tokenBytes is the number of random bytes in a generated token
+const tokenBytes = 32
+
+const insertResetTokenQuery = "insert into PasswordResetTokens(tokenHash,userID,expiresAt) values (?,?,?)"
+const selectResetTokenQuery = "select userID,expiresAt from PasswordResetTokens where tokenHash=?"
+const deleteResetTokenQuery = "delete from PasswordResetTokens where tokenHash=?"
+
+// newToken returns a new cryptographically random token encoded as hex
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of the token. Only this
+// hash is ever stored, so a leaked table can't be used to reset passwords
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createToken generates a new token, stores its hash for userID with an
+// expiry of ttl from now using insertQuery, and returns the plaintext token
+func (s *SQLStore) createToken(insertQuery string, userID int64, ttl time.Duration) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(ttl)
+	if _, err := s.db.Exec(insertQuery, hashToken(token), userID, expiresAt); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// CreatePasswordResetToken creates a single-use password reset token for the
+// given user that expires after ttl. The plaintext token is returned once
+// and only its hash is stored
+func (s *SQLStore) CreatePasswordResetToken(userID int64, ttl time.Duration) (string, error) {
+	return s.createToken(insertResetTokenQuery, userID, ttl)
+}
+
+// ConsumePasswordResetToken validates the given token and deletes it so it
+// can't be used again, returning the ID of the user it was issued for.
+// Returns ErrInvalidToken if the token is unknown or was already used, and
+// ErrTokenExpired if it is past its expiry
+func (s *SQLStore) ConsumePasswordResetToken(token string) (int64, error) {
+	tokenHash := hashToken(token)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var userID int64
+	var expiresAt time.Time
+	err = tx.QueryRow(selectResetTokenQuery, tokenHash).Scan(&userID, &expiresAt)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, ErrInvalidToken
+		}
+		return 0, err
+	}
+
+	// Delete the token whether or not it has expired so it can never be reused.
+	// If nothing was deleted, a concurrent call consumed it first
+	res, err := tx.Exec(deleteResetTokenQuery, tokenHash)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if deleted != 1 {
+		tx.Rollback()
+		return 0, ErrInvalidToken
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	if time.Now().After(expiresAt) {
+		return 0, ErrTokenExpired
+	}
+	return userID, nil
+}
+
+const insertVerificationTokenQuery = "insert into VerificationTokens(tokenHash,userID,expiresAt) values (?,?,?)"
+const selectByVerificationTokenQuery = "select u.id,u.email,u.passHash,u.username,u.firstName,u.lastName,u.photoUrl,t.expiresAt " +
+	"from VerificationTokens t join Users u on u.id=t.userID where t.tokenHash=?"
+
+// CreateVerificationToken creates an email verification token for the given
+// user that expires after ttl. The plaintext token is returned once and only
+// its hash is stored
+func (s *SQLStore) CreateVerificationToken(userID int64, ttl time.Duration) (string, error) {
+	return s.createToken(insertVerificationTokenQuery, userID, ttl)
+}
+
+// GetByVerificationToken returns the user the given verification token was
+// issued for. Returns ErrInvalidToken if the token is unknown and
+// ErrTokenExpired if it is past its expiry
+func (s *SQLStore) GetByVerificationToken(token string) (*User, error) {
+	user := &User{}
+	var expiresAt time.Time
+	err := s.db.QueryRow(selectByVerificationTokenQuery, hashToken(token)).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PassHash,
+		&user.UserName,
+		&user.FirstName,
+		&user.LastName,
+		&user.PhotoURL,
+		&expiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(expiresAt) {
+		return nil, ErrTokenExpired
+	}
+	return user, nil
+}
@@ -0,0 +1,236 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// capturedArg is a sqlmock argument matcher that accepts any value
+// and remembers it so the test can inspect it afterwards
+type capturedArg struct {
+	value driver.Value
+}
+
+func (a *capturedArg) Match(v driver.Value) bool {
+	a.value = v
+	return true
+}
+
+// TestCreatePasswordResetToken is a test function for the SQLStore's CreatePasswordResetToken
+func TestCreatePasswordResetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("There was a problem opening a database connection: [%v]", err)
+	}
+	defer db.Close()
+
+	mainSQLStore := &SQLStore{db}
+
+	tokenHash := &capturedArg{}
+	mock.ExpectExec(regexp.QuoteMeta(insertResetTokenQuery)).
+		WithArgs(tokenHash, int64(1), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	token, err := mainSQLStore.CreatePasswordResetToken(1, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error creating token: %v", err)
+	}
+	if len(token) != tokenBytes*2 {
+		t.Errorf("Error, expected a token of length [%d] but got [%d]", tokenBytes*2, len(token))
+	}
+	if tokenHash.value == token {
+		t.Errorf("Error, the plaintext token was stored instead of its hash")
+	}
+	if tokenHash.value != hashToken(token) {
+		t.Errorf("Error, expected stored hash [%s] but got [%v]", hashToken(token), tokenHash.value)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestConsumePasswordResetToken is a test function for the SQLStore's ConsumePasswordResetToken
+func TestConsumePasswordResetToken(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name           string
+		tokenExists    bool
+		rowsDeleted    int64
+		expiresAt      time.Time
+		expectedUserID int64
+		expectedError  error
+	}{
+		{
+			"Valid Token",
+			true,
+			1,
+			time.Now().Add(time.Hour),
+			1,
+			nil,
+		},
+		{
+			"Expired Token",
+			true,
+			1,
+			time.Now().Add(-time.Hour),
+			0,
+			ErrTokenExpired,
+		},
+		{
+			"Token Consumed Concurrently",
+			true,
+			0,
+			time.Now().Add(time.Hour),
+			0,
+			ErrInvalidToken,
+		},
+		{
+			"Unknown Or Already Used Token",
+			false,
+			0,
+			time.Time{},
+			0,
+			ErrInvalidToken,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+		token := "token123"
+
+		mock.ExpectBegin()
+		if c.tokenExists {
+			row := mock.NewRows([]string{"UserID", "ExpiresAt"}).AddRow(int64(1), c.expiresAt)
+			mock.ExpectQuery(regexp.QuoteMeta(selectResetTokenQuery)).WithArgs(hashToken(token)).WillReturnRows(row)
+			mock.ExpectExec(regexp.QuoteMeta(deleteResetTokenQuery)).WithArgs(hashToken(token)).WillReturnResult(sqlmock.NewResult(0, c.rowsDeleted))
+			if c.rowsDeleted == 1 {
+				mock.ExpectCommit()
+			} else {
+				// Another call deleted the token between our read and delete
+				mock.ExpectRollback()
+			}
+		} else {
+			// A used token has already been deleted, so no row comes back
+			row := mock.NewRows([]string{"UserID", "ExpiresAt"})
+			mock.ExpectQuery(regexp.QuoteMeta(selectResetTokenQuery)).WithArgs(hashToken(token)).WillReturnRows(row)
+			mock.ExpectRollback()
+		}
+
+		userID, err := mainSQLStore.ConsumePasswordResetToken(token)
+		if err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+		if userID != c.expectedUserID {
+			t.Errorf("Error, expected user ID [%d] but got [%d] in test [%s]", c.expectedUserID, userID, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+// TestGetByVerificationToken is a test function for the SQLStore's GetByVerificationToken
+func TestGetByVerificationToken(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		tokenExists   bool
+		expiresAt     time.Time
+		expectedUser  *User
+		expectedError error
+	}{
+		{
+			"Valid Token",
+			true,
+			time.Now().Add(time.Hour),
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			nil,
+		},
+		{
+			"Expired Token",
+			true,
+			time.Now().Add(-time.Hour),
+			nil,
+			ErrTokenExpired,
+		},
+		{
+			"Unknown Token",
+			false,
+			time.Time{},
+			nil,
+			ErrInvalidToken,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+		token := "token123"
+
+		row := mock.NewRows([]string{
+			"ID",
+			"Email",
+			"PassHash",
+			"UserName",
+			"FirstName",
+			"LastName",
+			"PhotoURL",
+			"ExpiresAt"},
+		)
+		if c.tokenExists {
+			row.AddRow(
+				int64(1),
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+				c.expiresAt,
+			)
+		}
+		// The token is looked up by its hash, never in plaintext
+		mock.ExpectQuery(regexp.QuoteMeta(selectByVerificationTokenQuery)).WithArgs(hashToken(token)).WillReturnRows(row)
+
+		// Test GetByVerificationToken()
+		user, err := mainSQLStore.GetByVerificationToken(token)
+		if err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+		if !reflect.DeepEqual(user, c.expectedUser) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package users
+
+import "bytes"
+
+// Equal reports whether u and other describe the same user, comparing
+// every field and using bytes.Equal for PassHash so a nil and an empty
+// hash are treated the same
+func (u *User) Equal(other *User) bool {
+	if u == nil || other == nil {
+		return u == other
+	}
+	return u.ID == other.ID &&
+		u.Email == other.Email &&
+		bytes.Equal(u.PassHash, other.PassHash) &&
+		u.UserName == other.UserName &&
+		u.FirstName == other.FirstName &&
+		u.LastName == other.LastName &&
+		u.PhotoURL == other.PhotoURL
+}
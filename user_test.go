@@ -0,0 +1,63 @@
+package users
+
+import "testing"
+
+// TestEqual is a test function for the User's Equal
+func TestEqual(t *testing.T) {
+	base := func() *User {
+		return &User{
+			1,
+			"test@test.com",
+			[]byte("passhash123"),
+			"username",
+			"firstname",
+			"lastname",
+			"photourl",
+		}
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name     string
+		user     *User
+		other    *User
+		expected bool
+	}{
+		{
+			"Equal Users",
+			base(),
+			base(),
+			true,
+		},
+		{
+			"Differing Field",
+			base(),
+			func() *User { u := base(); u.LastName = "other"; return u }(),
+			false,
+		},
+		{
+			"Differing PassHash",
+			base(),
+			func() *User { u := base(); u.PassHash = []byte("passhash456"); return u }(),
+			false,
+		},
+		{
+			"Nil And Empty PassHash",
+			func() *User { u := base(); u.PassHash = nil; return u }(),
+			func() *User { u := base(); u.PassHash = []byte{}; return u }(),
+			true,
+		},
+		{
+			"Nil Other User",
+			base(),
+			nil,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		if result := c.user.Equal(c.other); result != c.expected {
+			t.Errorf("Error, expected [%t] but got [%t] in test [%s]", c.expected, result, c.name)
+		}
+	}
+}
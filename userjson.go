@@ -0,0 +1,44 @@
+package users
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// UserJSON wraps a User so it can be stored in and read from a single JSON
+// column. A NULL column scans to a nil User and a nil User is written as NULL
+type UserJSON struct {
+	User *User
+}
+
+// Scan implements sql.Scanner
+func (uj *UserJSON) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		uj.User = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("error scanning user JSON: unsupported type %T", src)
+	}
+
+	user := &User{}
+	if err := json.Unmarshal(data, user); err != nil {
+		return fmt.Errorf("error scanning user JSON: %v", err)
+	}
+	uj.User = user
+	return nil
+}
+
+// Value implements driver.Valuer
+func (uj UserJSON) Value() (driver.Value, error) {
+	if uj.User == nil {
+		return nil, nil
+	}
+	return json.Marshal(uj.User)
+}
@@ -0,0 +1,78 @@
+package users
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestUserJSON is a test function for UserJSON's Scan and Value
+func TestUserJSON(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name        string
+		user        *User
+		asString    bool
+		expectError bool
+	}{
+		{
+			"Round Trip As Bytes",
+			&User{
+				1,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			false,
+			false,
+		},
+		{
+			"Round Trip As String",
+			&User{
+				1234567890,
+				"test@test.com",
+				[]byte("passhash123"),
+				"username",
+				"firstname",
+				"lastname",
+				"photourl",
+			},
+			true,
+			false,
+		},
+		{
+			"Round Trip NULL",
+			nil,
+			false,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		value, err := UserJSON{c.user}.Value()
+		if err != nil {
+			t.Errorf("Unexpected error getting value in test [%s]: %v", c.name, err)
+		}
+		if c.asString && value != nil {
+			value = string(value.([]byte))
+		}
+
+		scanned := &UserJSON{&User{}}
+		if err := scanned.Scan(value); err != nil {
+			t.Errorf("Unexpected error scanning in test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(scanned.User, c.user) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+	}
+
+	// Malformed JSON and unsupported types should fail without panicking
+	for _, src := range []interface{}{[]byte("{not json"), "", int64(1)} {
+		scanned := &UserJSON{}
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("Expected an error scanning [%v] but got none", src)
+		}
+	}
+}
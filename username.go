@@ -0,0 +1,130 @@
+package users
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrUserNameExists is returned when a username is already taken by another user
+var ErrUserNameExists = errors.New("username already exists")
+
+// ErrInvalidUserName is returned when a username is empty or contains whitespace
+var ErrInvalidUserName = errors.New("username must be non-empty and may not contain spaces")
+
+const selectUserNameForUpdateQuery = "select username from Users where id=? for update"
+const selectUserNameTakenQuery = "select id from Users where username=? and id<>? for update"
+const updateUserNameQuery = "update Users set username=? where id=?"
+
+// validateUserName checks that a username is non-empty, has no whitespace,
+// and is no longer than MaxUserNameLength
+func validateUserName(userName string) error {
+	if len(userName) == 0 || strings.IndexFunc(userName, unicode.IsSpace) >= 0 {
+		return ErrInvalidUserName
+	}
+	return validateLengths(&User{UserName: userName})
+}
+
+// UpdateUserName changes the username of the user with the given ID and
+// returns the updated user. Returns ErrUserNameExists if another user already
+// has that username and ErrUserNotFound if there is no user with the ID. The
+// check and update run in one transaction with the rows locked, so a
+// concurrent rename can't slip in between them
+func (s *SQLStore) UpdateUserName(id int64, newUserName string) (*User, error) {
+	if err := validateUserName(newUserName); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var currentUserName string
+	err = tx.QueryRow(selectUserNameForUpdateQuery, id).Scan(&currentUserName)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Renaming to the current username is a no-op, and MySQL would
+	// report 0 rows affected for it
+	if newUserName != currentUserName {
+		var takenBy int64
+		err = tx.QueryRow(selectUserNameTakenQuery, newUserName, id).Scan(&takenBy)
+		if err == nil {
+			tx.Rollback()
+			return nil, ErrUserNameExists
+		}
+		if err != sql.ErrNoRows {
+			tx.Rollback()
+			return nil, err
+		}
+
+		res, err := tx.Exec(updateUserNameQuery, newUserName, id)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if affected == 0 {
+			tx.Rollback()
+			return nil, ErrUserNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// UserNamesExist reports which of the given usernames are already taken,
+// using a single query. Every name passed in is a key in the returned map and
+// no other keys are added, even when the stored username differs in case.
+// An empty slice returns an empty map without querying
+func (s *SQLStore) UserNamesExist(names []string) (map[string]bool, error) {
+	taken := make(map[string]bool, len(names))
+	if len(names) == 0 {
+		return taken, nil
+	}
+
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		taken[name] = false
+		args = append(args, name)
+	}
+
+	query := "select username from Users where username in (?" + strings.Repeat(",?", len(names)-1) + ")"
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		// The DB may match case-insensitively and return the stored casing,
+		// so mark every input name it matches rather than adding a new key
+		for _, input := range names {
+			if strings.EqualFold(name, input) {
+				taken[input] = true
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return taken, nil
+}
@@ -0,0 +1,274 @@
+package users
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestUpdateUserName is a test function for the SQLStore's UpdateUserName
+func TestUpdateUserName(t *testing.T) {
+	updatedUser := &User{
+		1,
+		"test@test.com",
+		[]byte("passhash123"),
+		"newusername",
+		"firstname",
+		"lastname",
+		"photourl",
+	}
+	unchangedUser := &User{
+		1,
+		"test@test.com",
+		[]byte("passhash123"),
+		"username",
+		"firstname",
+		"lastname",
+		"photourl",
+	}
+
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		newUserName   string
+		userExists    bool
+		takenBy       int64
+		rowsUpdated   int64
+		expectedUser  *User
+		expectedError error
+	}{
+		{
+			"Successful Update",
+			"newusername",
+			true,
+			0,
+			1,
+			updatedUser,
+			nil,
+		},
+		{
+			"Unchanged UserName",
+			"username",
+			true,
+			0,
+			0,
+			unchangedUser,
+			nil,
+		},
+		{
+			"UserName Taken",
+			"takenusername",
+			true,
+			2,
+			0,
+			nil,
+			ErrUserNameExists,
+		},
+		{
+			"User Not Found",
+			"newusername",
+			false,
+			0,
+			0,
+			nil,
+			ErrUserNotFound,
+		},
+		{
+			"No Rows Updated",
+			"newusername",
+			true,
+			0,
+			0,
+			nil,
+			ErrUserNotFound,
+		},
+		{
+			"UserName With Spaces",
+			"new username",
+			false,
+			0,
+			0,
+			nil,
+			ErrInvalidUserName,
+		},
+		{
+			"Empty UserName",
+			"",
+			false,
+			0,
+			0,
+			nil,
+			ErrInvalidUserName,
+		},
+		{
+			"UserName Too Long",
+			strings.Repeat("a", MaxUserNameLength+1),
+			false,
+			0,
+			0,
+			nil,
+			ErrUserNameTooLong,
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+		var id int64 = 1
+
+		// Invalid usernames are rejected before any query runs
+		if c.expectedError != ErrInvalidUserName && c.expectedError != ErrUserNameTooLong {
+			mock.ExpectBegin()
+
+			// The user's row is locked first
+			currentRows := mock.NewRows([]string{"UserName"})
+			if c.userExists {
+				currentRows.AddRow("username")
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(selectUserNameForUpdateQuery)).WithArgs(id).WillReturnRows(currentRows)
+
+			if c.userExists && c.newUserName != "username" {
+				takenRows := mock.NewRows([]string{"ID"})
+				if c.takenBy != 0 {
+					takenRows.AddRow(c.takenBy)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(selectUserNameTakenQuery)).WithArgs(c.newUserName, id).WillReturnRows(takenRows)
+
+				if c.takenBy == 0 {
+					mock.ExpectExec(regexp.QuoteMeta(updateUserNameQuery)).WithArgs(c.newUserName, id).
+						WillReturnResult(sqlmock.NewResult(0, c.rowsUpdated))
+				}
+			}
+
+			if c.expectedError == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+		}
+
+		if c.expectedUser != nil {
+			// The refreshed user is read back through GetByID
+			row := mock.NewRows([]string{
+				"ID",
+				"Email",
+				"PassHash",
+				"UserName",
+				"FirstName",
+				"LastName",
+				"PhotoURL"},
+			).AddRow(
+				c.expectedUser.ID,
+				c.expectedUser.Email,
+				c.expectedUser.PassHash,
+				c.expectedUser.UserName,
+				c.expectedUser.FirstName,
+				c.expectedUser.LastName,
+				c.expectedUser.PhotoURL,
+			)
+			query := "select id,email,passHash,username,firstName,lastName,photoUrl from Users where id=?"
+			mock.ExpectQuery(query).WithArgs(id).WillReturnRows(row)
+		}
+
+		// Test UpdateUserName()
+		user, err := mainSQLStore.UpdateUserName(id, c.newUserName)
+		if err != c.expectedError {
+			t.Errorf("Expected error [%v] but got [%v] instead in test [%s]", c.expectedError, err, c.name)
+		}
+		if !reflect.DeepEqual(user, c.expectedUser) {
+			t.Errorf("Error, invalid match in test [%s]", c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations in test [%s]: %s", c.name, err)
+		}
+	}
+}
+
+// TestUserNamesExist is a test function for the SQLStore's UserNamesExist
+func TestUserNamesExist(t *testing.T) {
+	// Create a slice of test cases
+	cases := []struct {
+		name          string
+		names         []string
+		takenNames    []string
+		query         string
+		expectedTaken map[string]bool
+	}{
+		{
+			"Mix Of Taken And Free",
+			[]string{"alice", "bob", "carol"},
+			[]string{"alice", "carol"},
+			"select username from Users where username in (?,?,?)",
+			map[string]bool{"alice": true, "bob": false, "carol": true},
+		},
+		{
+			"Taken With Different Case",
+			[]string{"alice", "Bob"},
+			[]string{"Alice"},
+			"select username from Users where username in (?,?)",
+			map[string]bool{"alice": true, "Bob": false},
+		},
+		{
+			"All Free",
+			[]string{"dave"},
+			[]string{},
+			"select username from Users where username in (?)",
+			map[string]bool{"dave": false},
+		},
+		{
+			"Empty Slice",
+			[]string{},
+			nil,
+			"",
+			map[string]bool{},
+		},
+	}
+
+	for _, c := range cases {
+		// Create a new mock database for each case
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("There was a problem opening a database connection: [%v]", err)
+		}
+		defer db.Close()
+
+		mainSQLStore := &SQLStore{db}
+
+		// No query is expected for an empty slice
+		if len(c.names) > 0 {
+			args := make([]driver.Value, 0, len(c.names))
+			for _, name := range c.names {
+				args = append(args, name)
+			}
+			rows := mock.NewRows([]string{"UserName"})
+			for _, name := range c.takenNames {
+				rows.AddRow(name)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(c.query)).WithArgs(args...).WillReturnRows(rows)
+		}
+
+		// Test UserNamesExist()
+		taken, err := mainSQLStore.UserNamesExist(c.names)
+		if err != nil {
+			t.Errorf("Unexpected error on successful test [%s]: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(taken, c.expectedTaken) {
+			t.Errorf("Error, expected [%v] but got [%v] in test [%s]", c.expectedTaken, taken, c.name)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	}
+}